@@ -0,0 +1,188 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// In accessible mode, zones are described in plain sentences, one per
+// line, without colors or emoji, for screen readers and terminals
+// without emoji fonts. A line after them announces each move of the
+// clock, or of the highlighted zone.
+
+// ClockAscii is the plain text counterpart of ClockEmoji: the hour on a
+// 12-hour clock face, like "(3)".
+func (z Zone) ClockAscii(t time.Time) string {
+	h := z.currentTime(t).Hour() % 12
+	if h == 0 {
+		h = 12
+	}
+	return fmt.Sprintf("(%d)", h)
+}
+
+// Describe a duration in words, like "5 hours 30 minutes".
+func describeDuration(d time.Duration) string {
+	d = d.Abs().Round(time.Minute)
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	var parts []string
+	for _, part := range []struct {
+		n    int
+		unit string
+	}{{days, "day"}, {hours, "hour"}, {minutes, "minute"}} {
+		switch {
+		case part.n == 1:
+			parts = append(parts, "1 "+part.unit)
+		case part.n > 1:
+			parts = append(parts, fmt.Sprintf("%d %ss", part.n, part.unit))
+		}
+	}
+	if len(parts) == 0 {
+		return "0 minutes"
+	}
+	return strings.Join(parts, " ")
+}
+
+// Describe the difference of a zone with the local zone, the first.
+func (m *model) describeOffset(zone *Zone) string {
+	if zone == m.zones[0] {
+		return "local time"
+	}
+	_, offset := zone.currentTime(m.clock.t).Zone()
+	_, localOffset := m.zones[0].currentTime(m.clock.t).Zone()
+	difference := time.Duration(offset-localOffset) * time.Second
+	switch {
+	case difference > 0:
+		return describeDuration(difference) + " ahead of local"
+	case difference < 0:
+		return describeDuration(difference) + " behind local"
+	}
+	return "same time as local"
+}
+
+// Describe the time of day in a zone, from the kind of its band, or else
+// its name, like "evening".
+func (m *model) describeTimeOfDay(zone *Zone, t time.Time) string {
+	band := BandAt(m.zoneBands(zone), zone.currentTime(t).Hour())
+	switch {
+	case band == nil:
+		return ""
+	case band.Kind == WorkBand && m.isDayOff(zone, t):
+		return "day off"
+	case band.Kind == WorkBand:
+		return "working hours"
+	case band.Kind == SleepBand:
+		return "sleeping hours"
+	}
+	return band.Name
+}
+
+// Describe the clock's time in a zone, like "Paris, 15:30 Tuesday,
+// working hours, 6 hours ahead of local".
+func (m *model) describeZone(zone *Zone) string {
+	t := zone.currentTime(m.clock.t)
+	layout := "3:04PM Monday"
+	if m.zoneMilitary(zone) {
+		layout = "15:04 Monday"
+	}
+	parts := []string{zone.Name, formatLocal(t, layout)}
+	if timeOfDay := m.describeTimeOfDay(zone, m.clock.t); timeOfDay != "" {
+		parts = append(parts, timeOfDay)
+	}
+	if sun := m.describeSun(zone); m.showSun && sun != "" {
+		parts = append(parts, sun)
+	}
+	parts = append(parts, m.describeOffset(zone))
+	return strings.Join(parts, ", ")
+}
+
+// Describe the sunrise and sunset of the clock's day in a zone, like
+// "sunrise 07:21, sunset 17:25", or "" without coordinates.
+func (m *model) describeSun(zone *Zone) string {
+	day, rise, set, ok := m.sunTimes(zone)
+	switch {
+	case !ok:
+		return ""
+	case rise == "" && set == "" && day.Up:
+		return "sun up all day"
+	case rise == "" && set == "":
+		return "sun down all day"
+	case rise == "":
+		return "sunset " + set
+	case set == "":
+		return "sunrise " + rise
+	}
+	return fmt.Sprintf("sunrise %s, sunset %s", rise, set)
+}
+
+// Describe the clock's date and time in the local zone.
+func (m *model) describeClock() string {
+	layout := "3:04PM Monday 2 January 2006"
+	if m.zoneMilitary(m.zones[0]) {
+		layout = "15:04 Monday 2 January 2006"
+	}
+	return formatLocal(m.zones[0].currentTime(m.clock.t), layout)
+}
+
+// Announce what changed since the clock was at `previous`, and the
+// highlighted zone was `highlighted`. Other changes keep the last
+// announcement.
+func (m *model) announce(previous Clock, highlighted int) {
+	if len(m.zones) == 0 {
+		return
+	}
+	moved := m.clock.t.Sub(previous.t)
+	switch {
+	case m.clock.isRealTime && !previous.isRealTime:
+		m.announcement = "Back to now, " + m.describeClock()
+	case moved > 0 && !m.clock.isRealTime:
+		m.announcement = fmt.Sprintf("Moved %s later, to %s", describeDuration(moved), m.describeClock())
+	case moved < 0 && !m.clock.isRealTime:
+		m.announcement = fmt.Sprintf("Moved %s earlier, to %s", describeDuration(moved), m.describeClock())
+	case m.highlighted != highlighted && m.highlighted > 0:
+		m.announcement = "Selected " + m.describeZone(m.zones[m.highlighted-1])
+	case m.highlighted != highlighted:
+		m.announcement = "No zone selected"
+	}
+}
+
+// Render the zones as sentences, followed by the announcement line.
+func accessibleZones(m *model) string {
+	s := strings.Builder{}
+	for i, zone := range m.zones {
+		var prefix string
+		switch {
+		case m.ringing[i]:
+			prefix = "Alarm ringing: "
+		case i == m.highlighted-1:
+			prefix = "Selected: "
+		}
+		s.WriteString(fmt.Sprintf("  %s %s%s\n", zone.ClockAscii(m.clock.t), prefix, m.describeZone(zone)))
+	}
+
+	announcement := m.announcement
+	if announcement == "" {
+		announcement = "Clock at " + m.describeClock()
+	}
+	s.WriteString(fmt.Sprintf("\n  %s\n", announcement))
+	return s.String()
+}
@@ -0,0 +1,112 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDescribeDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{0, "0 minutes"},
+		{time.Minute, "1 minute"},
+		{-time.Hour, "1 hour"},
+		{5*time.Hour + 30*time.Minute, "5 hours 30 minutes"},
+		{49 * time.Hour, "2 days 1 hour"},
+	}
+	for _, test := range tests {
+		if got := describeDuration(test.duration); got != test.want {
+			t.Errorf("describeDuration(%v) = %q, want %q", test.duration, got, test.want)
+		}
+	}
+}
+
+func TestClockAscii(t *testing.T) {
+	zone := Zone{Loc: time.UTC, Name: "UTC"}
+	tests := map[int]string{0: "(12)", 3: "(3)", 12: "(12)", 23: "(11)"}
+	for hour, want := range tests {
+		if got := zone.ClockAscii(time.Date(2024, 10, 30, hour, 0, 0, 0, time.UTC)); got != want {
+			t.Errorf("ClockAscii at %d:00 = %q, want %q", hour, got, want)
+		}
+	}
+}
+
+func TestAnnounce(t *testing.T) {
+	paris, err := LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := model{
+		zones:      []*Zone{{Loc: time.UTC, Name: "UTC"}, {Loc: paris, Name: "Paris"}},
+		keymaps:    DefaultKeymaps,
+		clock:      *NewClockTime(time.Date(2024, 10, 30, 10, 0, 0, 0, time.UTC)),
+		isMilitary: true,
+		accessible: true,
+	}
+	tests := []struct {
+		key  tea.KeyMsg
+		want string
+	}{
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}}, "Moved 1 hour later, to 11:00 Wednesday 30 October 2024"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}}, "Moved 1 day earlier, to 11:00 Tuesday 29 October 2024"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}, "Selected UTC, 11:00 Tuesday, working hours, local time"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}, "Selected Paris, 12:00 Tuesday, working hours, 1 hour ahead of local"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}}, "Selected Paris, 12:00 Tuesday, working hours, 1 hour ahead of local"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}}, "Back to now, "},
+	}
+	for _, test := range tests {
+		m.Update(test.key)
+		if !strings.HasPrefix(m.announcement, test.want) {
+			t.Errorf("After %q, expected the announcement %q, but got %q", test.key, test.want, m.announcement)
+		}
+	}
+}
+
+func TestDescribeTimeOfDay(t *testing.T) {
+	zone := &Zone{Loc: time.UTC, Name: "UTC"}
+	m := model{
+		zones: []*Zone{zone},
+		bands: []Band{
+			{Name: "office", Start: 10, End: 19, Color: "day", Kind: WorkBand},
+			{Name: "bed", Start: 23, End: 7, Color: "night", Kind: SleepBand},
+			{Name: "commute", Start: 7, End: 10, Color: "morning"},
+		},
+	}
+	tests := []struct {
+		time string
+		want string
+	}{
+		{"2024-10-30T11:00:00Z", "working hours"},
+		{"2024-11-02T11:00:00Z", "day off"},
+		{"2024-10-30T02:00:00Z", "sleeping hours"},
+		{"2024-10-30T08:00:00Z", "commute"},
+		{"2024-10-30T20:00:00Z", ""},
+	}
+	for _, test := range tests {
+		at, _ := time.Parse(time.RFC3339, test.time)
+		if got := m.describeTimeOfDay(zone, at); got != test.want {
+			t.Errorf("At %s, expected %q, but got %q", test.time, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Weekdays, by their usual names and abbreviations.
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var (
+	everyDay = []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}
+	weekDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	weekEnds = []time.Weekday{time.Saturday, time.Sunday}
+)
+
+// Alarm rings at a given time of the day in a zone, either once, or
+// every day of the week in Days.
+type Alarm struct {
+	Spec   string // As written by the user
+	Label  string
+	Hour   int
+	Minute int
+	Loc    *time.Location
+	Days   []time.Weekday // Days to repeat on, or nil to ring once
+	Next   time.Time      // Next time to ring, or zero once rung
+}
+
+// ParseAlarm reads an alarm such as "15:00 America/New_York standup",
+// "every weekday 09:00 in Asia/Tokyo", or "2024-06-01 17:00 Europe/Paris
+// drinks". Without a zone, alarms ring in the local zone. Alarms without
+// a date, nor "every", ring once at the next occurrence after `now`, and
+// dated alarms before `now` never ring.
+func ParseAlarm(spec string, now time.Time) (*Alarm, error) {
+	alarm := &Alarm{Spec: spec}
+	tokens := strings.Fields(spec)
+	next := func() string {
+		if len(tokens) == 0 {
+			return ""
+		}
+		token := tokens[0]
+		tokens = tokens[1:]
+		return token
+	}
+
+	token := next()
+	if token == "every" {
+		days, err := parseAlarmDays(next())
+		if err != nil {
+			return nil, fmt.Errorf("invalid alarm %q: %w", spec, err)
+		}
+		alarm.Days = days
+		token = next()
+	}
+
+	var date string
+	if alarm.Days == nil && strings.Contains(token, "-") {
+		date = token
+		token = next()
+	}
+
+	hm, err := time.Parse("15:04", token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alarm %q: expected a time like 15:04", spec)
+	}
+	alarm.Hour = hm.Hour()
+	alarm.Minute = hm.Minute()
+
+	alarm.Loc = time.Local
+	if len(tokens) > 0 && tokens[0] == "in" {
+		next()
+		zone := next()
+		loc, err := LoadDeadlineLocation(zone)
+		if zone == "" || err != nil {
+			return nil, fmt.Errorf("invalid alarm %q: unknown zone %q", spec, zone)
+		}
+		alarm.Loc = loc
+	} else if len(tokens) > 0 {
+		if loc, err := LoadDeadlineLocation(tokens[0]); err == nil {
+			alarm.Loc = loc
+			next()
+		}
+	}
+	alarm.Label = strings.Join(tokens, " ")
+
+	if date != "" {
+		d, err := time.ParseInLocation("2006-01-02", date, alarm.Loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alarm %q: %w", spec, err)
+		}
+		alarm.Next = time.Date(d.Year(), d.Month(), d.Day(), alarm.Hour, alarm.Minute, 0, 0, alarm.Loc)
+		// A past alarm would ring on every launch: it never rings instead
+		if alarm.Next.Before(now) {
+			logger.Printf("Alarm %q is in the past, and won't ring", spec)
+			alarm.Next = time.Time{}
+		}
+	} else {
+		alarm.Next = alarm.nextAfter(now)
+	}
+	return alarm, nil
+}
+
+// Read the days of "every ...": "day", "weekday", "weekend", or a comma
+// separated list of day names, like "mon,wed,fri".
+func parseAlarmDays(s string) ([]time.Weekday, error) {
+	switch strings.ToLower(s) {
+	case "day", "days":
+		return everyDay, nil
+	case "weekday", "weekdays":
+		return weekDays, nil
+	case "weekend", "weekends":
+		return weekEnds, nil
+	}
+
+	var days []time.Weekday
+	for _, name := range strings.Split(strings.ToLower(s), ",") {
+		day, ok := weekdayNames[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown day %q", name)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// The first time the alarm should ring, strictly after `t`.
+func (a Alarm) nextAfter(t time.Time) time.Time {
+	tInZone := t.In(a.Loc)
+	for i := 0; i <= 7; i++ {
+		candidate := time.Date(tInZone.Year(), tInZone.Month(), tInZone.Day()+i, a.Hour, a.Minute, 0, 0, a.Loc)
+		if !candidate.After(t) {
+			continue
+		}
+		if a.Days == nil || slices.Contains(a.Days, candidate.Weekday()) {
+			return candidate
+		}
+	}
+	return time.Time{}
+}
+
+// Due reports whether the alarm should ring at time `now`, and if so,
+// schedules its next occurrence.
+func (a *Alarm) Due(now time.Time) bool {
+	if a.Next.IsZero() || now.Before(a.Next) {
+		return false
+	}
+	if a.Days == nil {
+		a.Next = time.Time{}
+	} else {
+		a.Next = a.nextAfter(now)
+	}
+	return true
+}
+
+// Describe the alarm at the time it rings.
+func (a Alarm) String() string {
+	label := a.Label
+	if label == "" {
+		label = "Alarm"
+	}
+	return fmt.Sprintf("%s: %02d:%02d %s", label, a.Hour, a.Minute, a.Loc)
+}
+
+// Run the user's alarm command through the shell, passing the alarm's
+// details in TZ_ALARM_* environment variables.
+func runAlarmCommand(command string, a Alarm, at time.Time) tea.Cmd {
+	return func() tea.Msg {
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.Command("cmd", "/C", command)
+		} else {
+			cmd = exec.Command("sh", "-c", command)
+		}
+		cmd.Env = append(
+			os.Environ(),
+			"TZ_ALARM_SPEC="+a.Spec,
+			"TZ_ALARM_LABEL="+a.Label,
+			"TZ_ALARM_ZONE="+a.Loc.String(),
+			"TZ_ALARM_TIME="+at.In(a.Loc).Format(time.RFC3339),
+		)
+		if err := cmd.Run(); err != nil {
+			logger.Printf("Alarm command %q failed: %v", command, err)
+		}
+		return nil
+	}
+}
+
+// Add an alarm from the TUI prompt.
+func (m *model) addAlarm(spec string) error {
+	alarm, err := ParseAlarm(spec, time.Now())
+	if err != nil {
+		return err
+	}
+	if alarm.Next.IsZero() {
+		return fmt.Errorf("alarm %q would never ring", spec)
+	}
+	m.alarms = append(m.alarms, alarm)
+	m.message = fmt.Sprintf("Alarm set for %s", alarm.Next.Format("Mon Jan 02 15:04 MST"))
+	return nil
+}
+
+// Ring an alarm scheduled at time `at`: flash the rows of the alarm's
+// zone, by name so that the local zone matches its tzdata name, ring the
+// bell, and run the alarm command if any.
+func (m *model) ring(a *Alarm, at time.Time) tea.Cmd {
+	if m.ringing == nil {
+		m.ringing = make(map[int]bool)
+	}
+	name := locationName(a.Loc)
+	for i, zone := range m.zones {
+		if locationName(zone.Loc) == name {
+			m.ringing[i] = true
+		}
+	}
+	if m.accessible {
+		m.message = "Alarm: " + a.String()
+	} else {
+		m.message = "🔔 " + a.String()
+	}
+	logger.Printf("Ringing alarm %q", a.Spec)
+
+	m.bell = true
+	if m.alarmCommand != "" {
+		return runAlarmCommand(m.alarmCommand, *a, at)
+	}
+	return nil
+}
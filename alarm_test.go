@@ -0,0 +1,243 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseAlarm(t *testing.T) {
+	// Friday
+	now := time.Date(2024, time.May, 31, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		spec  string
+		label string
+		zone  string
+		next  string
+		ok    bool
+	}{
+		{"15:00 America/New_York standup", "standup", "America/New_York", "2024-05-31T19:00:00Z", true},
+		{"13:00 America/New_York standup", "standup", "America/New_York", "2024-06-01T17:00:00Z", true},
+		{"every weekday 09:00 in Asia/Tokyo", "", "Asia/Tokyo", "2024-06-03T00:00:00Z", true},
+		{"every sat,sun 12:00 UTC lunch break", "lunch break", "UTC", "2024-06-01T12:00:00Z", true},
+		{"2024-06-01 17:00 Europe/Paris drinks", "drinks", "Europe/Paris", "2024-06-01T15:00:00Z", true},
+		{"2024-05-01 17:00 Europe/Paris drinks", "drinks", "Europe/Paris", "0001-01-01T00:00:00Z", true},
+		{"every fortnight 09:00", "", "", "", false},
+		{"09:00 in Nowhere/Land", "", "", "", false},
+		{"standup", "", "", "", false},
+	}
+	for _, test := range tests {
+		alarm, err := ParseAlarm(test.spec, now)
+		if test.ok != (err == nil) {
+			t.Errorf("Expected %v for %q, but got: %v", test.ok, test.spec, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if alarm.Label != test.label {
+			t.Errorf("Expected label %q for %q, but got %q", test.label, test.spec, alarm.Label)
+		}
+		if alarm.Loc.String() != test.zone {
+			t.Errorf("Expected zone %q for %q, but got %q", test.zone, test.spec, alarm.Loc)
+		}
+		next := alarm.Next.UTC().Format(time.RFC3339)
+		if next != test.next {
+			t.Errorf("Expected %q to ring at %s, but got %s", test.spec, test.next, next)
+		}
+	}
+}
+
+func TestAlarmDue(t *testing.T) {
+	now := time.Date(2024, time.May, 31, 18, 0, 0, 0, time.UTC)
+	once, _ := ParseAlarm("19:00 UTC", now)
+	daily, _ := ParseAlarm("every day 19:00 UTC", now)
+
+	for _, alarm := range []*Alarm{once, daily} {
+		if alarm.Due(now.Add(59 * time.Minute)) {
+			t.Errorf("%q rang too early", alarm.Spec)
+		}
+		if !alarm.Due(now.Add(time.Hour + time.Second)) {
+			t.Errorf("%q did not ring", alarm.Spec)
+		}
+		if alarm.Due(now.Add(time.Hour + 2*time.Second)) {
+			t.Errorf("%q rang twice", alarm.Spec)
+		}
+	}
+
+	if !once.Next.IsZero() {
+		t.Errorf("Expected single alarm to be done, but it will ring at %v", once.Next)
+	}
+	if !daily.Due(now.Add(25 * time.Hour)) {
+		t.Errorf("Expected daily alarm to ring the next day")
+	}
+}
+
+func TestUpdateAlarms(t *testing.T) {
+	m := model{
+		zones:   LoadDstTestZones(t),
+		keymaps: DefaultKeymaps,
+		clock:   *NewClockTime(utcMinuteAfterMidnightTime),
+	}
+
+	// "a" key, then type the alarm and press enter
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if m.prompt == nil {
+		t.Fatal("Expected alarm prompt to open")
+	}
+	for _, word := range []string{"every", "day", "09:00", "Europe/Paris"} {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(word)})
+		m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.prompt != nil {
+		t.Fatal("Expected alarm prompt to close")
+	}
+	if len(m.alarms) != 1 {
+		t.Fatalf("Expected 1 alarm, but got %d", len(m.alarms))
+	}
+
+	// Tick past the alarm
+	m.Update(tickMsg(m.alarms[0].Next.Add(time.Second)))
+	if !strings.Contains(m.message, "09:00 Europe/Paris") {
+		t.Errorf("Expected alarm message, but got %q", m.message)
+	}
+	paris := 1
+	if !m.ringing[paris] || len(m.ringing) != 1 {
+		t.Errorf("Expected Paris row to ring, but got %v", m.ringing)
+	}
+
+	// Any key acknowledges the alarm
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	if m.ringing != nil || m.message != "" {
+		t.Errorf("Expected alarm to be acknowledged")
+	}
+}
+
+func TestResync(t *testing.T) {
+	now := time.Now()
+	daily, err := ParseAlarm("every day 09:00 UTC", now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	once, err := ParseAlarm("2030-01-01 09:00 UTC", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	onceNext := once.Next
+	m := model{
+		zones:  DefaultZones,
+		alarms: []*Alarm{daily, once},
+		clock:  Clock{t: now.Add(-time.Hour), isRealTime: true},
+	}
+
+	// The wall clock went back a day: the daily alarm must not skip today
+	m.resync(now, -24*time.Hour)
+	if want := daily.nextAfter(now); !daily.Next.Equal(want) {
+		t.Errorf("Expected the daily alarm at %v, but got %v", want, daily.Next)
+	}
+	if !once.Next.Equal(onceNext) {
+		t.Errorf("Expected the one-off alarm to stay at %v, but got %v", onceNext, once.Next)
+	}
+	if !m.clock.isRealTime || m.clock.t.Before(now) {
+		t.Errorf("Expected the clock to catch up with now, but got %v", m.clock.t)
+	}
+
+	// A clock moved away from now stays where it is
+	moved := *NewClockTime(now.Add(-48 * time.Hour))
+	m.clock = moved
+	m.resync(now, time.Hour)
+	if m.clock != moved {
+		t.Errorf("Expected the clock to stay at %v, but got %v", moved.t, m.clock.t)
+	}
+}
+
+func TestRing(t *testing.T) {
+	t.Setenv("TZ", "Europe/Paris")
+	zones := LoadDstTestZones(t)
+	at := time.Date(2024, time.June, 1, 7, 0, 0, 0, time.UTC)
+	tests := []struct {
+		spec    string
+		ringing []int
+		silent  []int
+	}{
+		{"09:00", []int{0, 2}, []int{1}},
+		{"09:00 Europe/Paris", []int{0, 2}, []int{1}},
+		{"09:00 Europe/Berlin", nil, []int{0, 1, 2}},
+		{"07:00 UTC", []int{1}, []int{0, 2}},
+	}
+	for _, test := range tests {
+		alarm, err := ParseAlarm(test.spec, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := model{zones: []*Zone{DefaultZones[0], zones[0], zones[1]}}
+		m.ring(alarm, at)
+		for _, row := range test.ringing {
+			if !m.ringing[row] {
+				t.Errorf("%q: expected the row of %s to flash", test.spec, m.zones[row].Name)
+			}
+		}
+		for _, row := range test.silent {
+			if m.ringing[row] {
+				t.Errorf("%q: expected the row of %s not to flash", test.spec, m.zones[row].Name)
+			}
+		}
+		if !m.bell {
+			t.Errorf("%q: expected the bell to ring", test.spec)
+		}
+		if !strings.HasSuffix(m.View(), "\a") {
+			t.Errorf("%q: expected the bell in the view", test.spec)
+		}
+	}
+}
+
+func TestAddPastAlarm(t *testing.T) {
+	m := model{zones: DefaultZones}
+	if err := m.addAlarm("2020-01-01 09:00 UTC"); err == nil {
+		t.Errorf("Expected an alarm in the past to be refused")
+	}
+	if len(m.alarms) != 0 {
+		t.Errorf("Expected no alarm, but got %v", m.alarms)
+	}
+}
+
+func TestBellRingsOnce(t *testing.T) {
+	m := model{
+		zones:   DefaultZones,
+		keymaps: DefaultKeymaps,
+		clock:   *NewClockTime(utcMinuteAfterMidnightTime),
+	}
+	alarm, err := ParseAlarm("09:00 UTC", utcMinuteAfterMidnightTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.alarms = []*Alarm{alarm}
+	m.Update(tickMsg(alarm.Next))
+	if !strings.HasSuffix(m.View(), "\a") {
+		t.Errorf("Expected the bell in the view after the alarm rang")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	if strings.Contains(m.View(), "\a") {
+		t.Errorf("Expected the bell to ring only once")
+	}
+}
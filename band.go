@@ -0,0 +1,171 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
+)
+
+// Band is a named range of hours of the day, colored in the timelines.
+type Band struct {
+	Name   string
+	Start  int    // First hour of the band
+	End    int    // Hour the band ends at, before Start when it spans midnight
+	Color  string // A color, or one of the morning, day, evening and night colors of the theme
+	Symbol string // Marks the hours of the band in plain text, where colors are not shown
+	Kind   string // What people do in the band: work, sleep, or "" for neither
+}
+
+// Kinds of bands, for the week overview and the accessible descriptions.
+const (
+	WorkBand  = "work"
+	SleepBand = "sleep"
+)
+
+// DefaultBands are the times of day of the timelines, unless configured.
+var DefaultBands = []Band{
+	{Name: "morning", Start: 7, End: 9, Color: "morning"},
+	{Name: "day", Start: 9, End: 18, Color: "day", Kind: WorkBand},
+	{Name: "evening", Start: 18, End: 20, Color: "evening"},
+	{Name: "night", Start: 20, End: 7, Color: "night", Kind: SleepBand},
+}
+
+// Contains reports whether the band includes the hour.
+func (b Band) Contains(hour int) bool {
+	if b.Start <= b.End {
+		return hour >= b.Start && hour < b.End
+	}
+	return hour >= b.Start || hour < b.End
+}
+
+// Resolve the band's color with the palette in use.
+func (b Band) color() string {
+	switch b.Color {
+	case "morning":
+		return palette().Morning
+	case "day":
+		return palette().Day
+	case "evening":
+		return palette().Evening
+	case "night":
+		return palette().Night
+	}
+	return b.Color
+}
+
+func (b Band) String() string {
+	return fmt.Sprintf("%s %d-%d", b.Name, b.Start, b.End)
+}
+
+// BandAt finds the first band including the hour, or nil.
+func BandAt(bands []Band, hour int) *Band {
+	for i := range bands {
+		if bands[i].Contains(hour) {
+			return &bands[i]
+		}
+	}
+	return nil
+}
+
+// CheckBand validates the hours, color, symbol and kind of a band.
+func CheckBand(b Band) error {
+	if b.Name == "" {
+		return fmt.Errorf("bands need a name")
+	}
+	if b.Start < 0 || b.Start > 23 || b.End < 0 || b.End > 24 || b.Start == b.End {
+		return fmt.Errorf("band %s: invalid hours %d-%d", b.Name, b.Start, b.End)
+	}
+	switch b.Color {
+	case "morning", "day", "evening", "night":
+	default:
+		if err := CheckColor(b.Color); err != nil {
+			return fmt.Errorf("band %s: %w", b.Name, err)
+		}
+	}
+	if runewidth.StringWidth(b.Symbol) > 1 {
+		return fmt.Errorf("band %s: symbol %q is wider than one column", b.Name, b.Symbol)
+	}
+	switch b.Kind {
+	case "", WorkBand, SleepBand:
+	default:
+		return fmt.Errorf("band %s: unknown kind %q, expected %s or %s", b.Name, b.Kind, WorkBand, SleepBand)
+	}
+	return nil
+}
+
+// Bands of a zone: its own, or else the configured ones, or the default
+// ones.
+func (m *model) zoneBands(zone *Zone) []Band {
+	switch {
+	case len(zone.Bands) > 0:
+		return zone.Bands
+	case len(m.bands) > 0:
+		return m.bands
+	}
+	return DefaultBands
+}
+
+// Color of an hour in the timeline of a zone.
+func (m *model) hourColor(zone *Zone, hour int) string {
+	if band := BandAt(m.zoneBands(zone), hour); band != nil {
+		return band.color()
+	}
+	return palette().Text
+}
+
+// Symbol marking an hour of a zone in plain text, or a space.
+func (m *model) hourSymbol(zone *Zone, hour int) string {
+	if term != termenv.Ascii {
+		return " "
+	}
+	if band := BandAt(m.zoneBands(zone), hour); band != nil && band.Symbol != "" {
+		return fitWidth(band.Symbol, 1)
+	}
+	return " "
+}
+
+// Legend of the bands, in their colors, for the help overlay: one line
+// for the bands of all zones, and one for each zone with its own bands.
+func bandLegend(m *model) []string {
+	legend := func(label string, bands []Band) string {
+		items := []string{dateTimeStyle(label).String()}
+		for _, band := range bands {
+			item := band.String()
+			if band.Symbol != "" {
+				item = fmt.Sprintf("%s %s", band.Symbol, item)
+			}
+			items = append(items, termenv.String("■ "+item).Foreground(term.Color(band.color())).String())
+		}
+		return "  " + strings.Join(items, "  ")
+	}
+
+	bands := m.bands
+	if len(bands) == 0 {
+		bands = DefaultBands
+	}
+	lines := []string{legend(tr("Hours:"), bands)}
+	for _, zone := range m.zones {
+		if len(zone.Bands) > 0 {
+			lines = append(lines, legend(zone.Name+":", zone.Bands))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,110 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBandAt(t *testing.T) {
+	bands := []Band{
+		{Name: "work", Start: 9, End: 17, Color: "day"},
+		{Name: "sleep", Start: 23, End: 7, Color: "night"},
+	}
+	tests := []struct {
+		hour     int
+		expected string
+	}{
+		{0, "sleep"},
+		{6, "sleep"},
+		{7, ""},
+		{9, "work"},
+		{16, "work"},
+		{17, ""},
+		{23, "sleep"},
+	}
+	for _, test := range tests {
+		band := BandAt(bands, test.hour)
+		observed := ""
+		if band != nil {
+			observed = band.Name
+		}
+		if observed != test.expected {
+			t.Errorf("Expected band %q at %d, but got %q", test.expected, test.hour, observed)
+		}
+	}
+
+	for hour := 0; hour < 24; hour++ {
+		if BandAt(DefaultBands, hour) == nil {
+			t.Errorf("Expected a default band at %d", hour)
+		}
+	}
+}
+
+func TestCheckBand(t *testing.T) {
+	tests := []struct {
+		band Band
+		ok   bool
+	}{
+		{Band{Name: "work", Start: 9, End: 17, Color: "day"}, true},
+		{Band{Name: "late", Start: 20, End: 24, Color: "#5957C9", Symbol: "z"}, true},
+		{Band{Name: "sleep", Start: 23, End: 7, Color: "93"}, true},
+		{Band{Start: 9, End: 17, Color: "day"}, false},
+		{Band{Name: "work", Start: 9, End: 9, Color: "day"}, false},
+		{Band{Name: "work", Start: 24, End: 2, Color: "day"}, false},
+		{Band{Name: "work", Start: 9, End: 25, Color: "day"}, false},
+		{Band{Name: "work", Start: 9, End: 17, Color: "dawn"}, false},
+		{Band{Name: "work", Start: 9, End: 17, Color: "day", Symbol: "**"}, false},
+		{Band{Name: "office", Start: 9, End: 17, Color: "day", Kind: WorkBand}, true},
+		{Band{Name: "office", Start: 9, End: 17, Color: "day", Kind: "day"}, false},
+	}
+	for _, test := range tests {
+		err := CheckBand(test.band)
+		if test.ok != (err == nil) {
+			t.Errorf("Expected band %+v to be valid: %v, but got: %v", test.band, test.ok, err)
+		}
+	}
+}
+
+func TestZoneBands(t *testing.T) {
+	own := []Band{{Name: "focus", Start: 9, End: 12, Color: "#FFB000", Symbol: "*"}}
+	configured := []Band{{Name: "work", Start: 9, End: 17, Color: "day", Symbol: "+"}}
+	zoneWithBands := &Zone{Name: "Focused", Bands: own}
+	zone := &Zone{Name: "Other"}
+
+	m := model{zones: []*Zone{zone, zoneWithBands}}
+	if bands := m.zoneBands(zone); bands[0].Name != DefaultBands[0].Name {
+		t.Errorf("Expected the default bands, but got %v", bands)
+	}
+
+	m.bands = configured
+	if bands := m.zoneBands(zone); bands[0].Name != "work" {
+		t.Errorf("Expected the configured bands, but got %v", bands)
+	}
+	if bands := m.zoneBands(zoneWithBands); bands[0].Name != "focus" {
+		t.Errorf("Expected the zone's own bands, but got %v", bands)
+	}
+	if m.hourColor(zoneWithBands, 10) != "#FFB000" {
+		t.Errorf("Expected the focus color, but got %v", m.hourColor(zoneWithBands, 10))
+	}
+
+	legend := strings.Join(bandLegend(&m), "\n")
+	if !strings.Contains(legend, "+ work 9-17") || !strings.Contains(legend, "Focused:") || !strings.Contains(legend, "* focus 9-12") {
+		t.Errorf("Expected a legend of all bands, but got:\n%v", legend)
+	}
+}
@@ -0,0 +1,155 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Glyphs of the big clock, 3 by 5 pixels, # when lit.
+var bigGlyphs = map[rune][5]string{
+	'0': {"###", "# #", "# #", "# #", "###"},
+	'1': {" # ", "## ", " # ", " # ", "###"},
+	'2': {"###", "  #", "###", "#  ", "###"},
+	'3': {"###", "  #", "###", "  #", "###"},
+	'4': {"# #", "# #", "###", "  #", "  #"},
+	'5': {"###", "#  ", "###", "  #", "###"},
+	'6': {"###", "#  ", "###", "# #", "###"},
+	'7': {"###", "  #", "  #", "  #", "  #"},
+	'8': {"###", "# #", "###", "# #", "###"},
+	'9': {"###", "# #", "###", "  #", "###"},
+	':': {"   ", " # ", "   ", " # ", "   "},
+	'A': {"###", "# #", "###", "# #", "# #"},
+	'P': {"###", "# #", "###", "#  ", "#  "},
+	'M': {"# #", "###", "###", "# #", "# #"},
+}
+
+// Height of the glyphs of the big clock, in pixels.
+const BigGlyphHeight = 5
+
+// Lines of the big clock besides its digits: the name, date and offset
+// of the zone, the blank lines around them, and the status bar.
+const BigClockMargin = 7
+
+// How long each zone shows on the big clock, when none is highlighted.
+const BigClockRotation = 10 * time.Second
+
+// Zone of the big clock: the highlighted one, or else each zone in turn,
+// following the watch ticks, or the current time before the first tick.
+func (m *model) bigClockZone() *Zone {
+	if m.highlighted > 0 && m.highlighted <= len(m.zones) {
+		return m.zones[m.highlighted-1]
+	}
+	at := m.lastTick
+	if at.IsZero() {
+		at = time.Now()
+	}
+	count := int64(len(m.zones))
+	turn := at.Unix() / int64(BigClockRotation/time.Second)
+	return m.zones[int((turn%count+count)%count)]
+}
+
+// Render `text` in big glyphs, each pixel `scale` rows high and twice as
+// many columns wide, so that they look square.
+func bigText(text string, scale int) []string {
+	lines := make([]string, BigGlyphHeight*scale)
+	for y := range lines {
+		row := strings.Builder{}
+		for i, r := range text {
+			if i > 0 {
+				row.WriteString(strings.Repeat(" ", 2*scale))
+			}
+			for _, pixel := range bigGlyphs[r][y/scale] {
+				lit := " "
+				if pixel == '#' {
+					lit = "█"
+				}
+				row.WriteString(strings.Repeat(lit, 2*scale))
+			}
+		}
+		lines[y] = row.String()
+	}
+	return lines
+}
+
+// Width of `text` in big glyphs, in columns.
+func bigTextWidth(text string, scale int) int {
+	glyphs := len([]rune(text))
+	return glyphs*3*2*scale + (glyphs-1)*2*scale
+}
+
+// Center `s` in `width` columns.
+func center(s string, width int) string {
+	padding := max(0, (width-runewidth.StringWidth(s))/2)
+	return strings.Repeat(" ", padding) + s
+}
+
+// Render the time in the zone of the big clock, as large as fits the
+// terminal, with its name, date and offset under it.
+func bigClock(m *model, width int) string {
+	zone := m.bigClockZone()
+	timeInZone := zone.currentTime(m.clock.t)
+
+	layout := "3:04PM"
+	switch {
+	case m.zoneMilitary(zone) && m.showSeconds:
+		layout = "15:04:05"
+	case m.zoneMilitary(zone):
+		layout = "15:04"
+	case m.showSeconds:
+		layout = "3:04:05PM"
+	}
+	text := timeInZone.Format(layout)
+
+	// Grow the digits until they don't fit
+	scale := 1
+	for bigTextWidth(text, scale+1) <= width-4 &&
+		(m.termHeight == 0 || BigGlyphHeight*(scale+1)+BigClockMargin <= m.termHeight) {
+		scale++
+	}
+
+	s := strings.Builder{}
+	s.WriteString("\n")
+	for _, line := range bigText(text, scale) {
+		s.WriteString(normalTextStyle(center(line, width)).String() + "\n")
+	}
+
+	_, offset := timeInZone.Zone()
+	s.WriteString("\n")
+	s.WriteString(normalTextStyle(center(zone.Name, width)).String() + "\n")
+	s.WriteString(dateTimeStyle(center(formatLocal(timeInZone, "Monday 2 January 2006"), width)).String() + "\n")
+	s.WriteString(dateTimeStyle(center(fmt.Sprintf("UTC%s, %s", formatOffset(offset), m.describeOffset(zone)), width)).String() + "\n")
+	return s.String()
+}
+
+// The big clock fills the whole terminal, with only the status bar under
+// it.
+func (m model) bigClockView() string {
+	width := m.termWidth
+	if width == 0 {
+		width = m.viewWidth()
+	}
+	s := bigClock(&m, width)
+	if m.interactive {
+		s += status(m)
+	}
+	return s
+}
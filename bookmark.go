@@ -0,0 +1,194 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Bookmark is a named clock position.
+type Bookmark struct {
+	Name string    `toml:"name"`
+	Time time.Time `toml:"time"`
+}
+
+// State is what tz remembers between sessions.
+type State struct {
+	Bookmarks []Bookmark `toml:"bookmarks"`
+}
+
+// DefaultStateFile is next to the config file.
+func DefaultStateFile() (*string, error) {
+	configFile, err := DefaultConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	stateFilePath := filepath.Join(filepath.Dir(*configFile), "state.toml")
+	return &stateFilePath, nil
+}
+
+// LoadState reads the state file, if any.
+func LoadState(stateFilePath string) (*State, error) {
+	state := State{}
+
+	data, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		logger.Printf("State file '%s' not found. Skipping...\n", stateFilePath)
+		return &state, nil
+	}
+	if err = toml.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("Parsing %s: %w", stateFilePath, err)
+	}
+	return &state, nil
+}
+
+// OpenState finds and loads the state file. Bookmarks are not worth
+// failing to start over: on errors, it logs them and returns an empty
+// state, without a file to save it to, so as not to overwrite one it
+// could not read.
+func OpenState() (string, *State) {
+	stateFile, err := DefaultStateFile()
+	if err != nil {
+		logger.Printf("State error: %s, bookmarks won't be saved", err)
+		return "", &State{}
+	}
+	return openStateFile(*stateFile)
+}
+
+func openStateFile(stateFilePath string) (string, *State) {
+	state, err := LoadState(stateFilePath)
+	if err != nil {
+		logger.Printf("State error: %s, bookmarks won't be saved", err)
+		return "", &State{}
+	}
+	return stateFilePath, state
+}
+
+// Save writes the state file, creating its directory if needed.
+func (s State) Save(stateFilePath string) error {
+	data, err := toml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(stateFilePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(stateFilePath, data, 0644)
+}
+
+// Bookmark the clock's time under a name, replacing any bookmark with the
+// same name.
+func (m *model) addBookmark(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("Bookmarks need a name")
+	}
+
+	bookmark := Bookmark{Name: name, Time: m.clock.t}
+	replaced := false
+	for i := range m.bookmarks {
+		if m.bookmarks[i].Name == name {
+			m.bookmarks[i] = bookmark
+			replaced = true
+		}
+	}
+	if !replaced {
+		m.bookmarks = append(m.bookmarks, bookmark)
+	}
+	m.message = fmt.Sprintf("Bookmarked %s", name)
+	return m.saveBookmarks()
+}
+
+func (m *model) saveBookmarks() error {
+	if m.stateFile == "" {
+		return nil
+	}
+	state := State{Bookmarks: m.bookmarks}
+	if err := state.Save(m.stateFile); err != nil {
+		return fmt.Errorf("Saving bookmarks: %w", err)
+	}
+	return nil
+}
+
+// Handle keys of the bookmarks overlay: select with the line keys, jump
+// with enter, delete, or close. Returns false for keys it ignores.
+func (m *model) updateBookmarks(msg tea.KeyMsg) bool {
+	key := msg.String()
+	switch {
+	case match(key, m.keymaps.PrevLine):
+		if len(m.bookmarks) > 0 {
+			m.selectedBookmark = (m.selectedBookmark - 1 + len(m.bookmarks)) % len(m.bookmarks)
+		}
+
+	case match(key, m.keymaps.NextLine):
+		if len(m.bookmarks) > 0 {
+			m.selectedBookmark = (m.selectedBookmark + 1) % len(m.bookmarks)
+		}
+
+	case key == "enter":
+		if m.selectedBookmark < len(m.bookmarks) {
+			bookmark := m.bookmarks[m.selectedBookmark]
+			m.history.Push(m.clock)
+			m.clock = *NewClockTime(bookmark.Time.In(m.clock.t.Location()))
+			m.message = fmt.Sprintf("Jumped to %s", bookmark.Name)
+		}
+		m.showBookmarks = false
+
+	case key == "delete" || key == "backspace":
+		if m.selectedBookmark < len(m.bookmarks) {
+			m.bookmarks = append(m.bookmarks[:m.selectedBookmark], m.bookmarks[m.selectedBookmark+1:]...)
+			m.selectedBookmark = max(0, min(m.selectedBookmark, len(m.bookmarks)-1))
+			if err := m.saveBookmarks(); err != nil {
+				m.message = err.Error()
+			}
+		}
+
+	case key == "esc" || match(key, m.keymaps.Bookmarks):
+		m.showBookmarks = false
+
+	default:
+		return false
+	}
+	return true
+}
+
+// Render the bookmarks overlay.
+func bookmarksPanel(m *model) string {
+	if len(m.bookmarks) == 0 {
+		return fmt.Sprintf("  %s\n", dateTimeStyle(fmt.Sprintf("No bookmarks: press %s to add one", m.keymaps.AddBookmark[0])))
+	}
+
+	s := strings.Builder{}
+	for i, bookmark := range m.bookmarks {
+		marker := "  "
+		if i == m.selectedBookmark {
+			marker = highlightStyle(">>").String()
+		}
+		when := bookmark.Time.In(m.clock.t.Location()).Format("Mon Jan 02 2006, 15:04 MST")
+		name := fmt.Sprintf("%-30s", bookmark.Name)
+		s.WriteString(fmt.Sprintf("%s %s %s\n", marker, normalTextStyle(name), dateTimeStyle(when)))
+	}
+	s.WriteString(fmt.Sprintf("   %s\n", dateTimeStyle("enter: jump, del: delete, esc: close")))
+	return s.String()
+}
@@ -0,0 +1,137 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStateFile(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "tz", "state.toml")
+
+	state, err := LoadState(stateFile)
+	if err != nil || len(state.Bookmarks) != 0 {
+		t.Fatalf("Expected empty state from missing file, but got %v, %v", state, err)
+	}
+
+	state.Bookmarks = []Bookmark{{"Candidate slot", utcMinuteAfterMidnightTime.Truncate(time.Second)}}
+	if err := state.Save(stateFile); err != nil {
+		t.Fatalf("Could not save state: %v", err)
+	}
+
+	loaded, err := LoadState(stateFile)
+	if err != nil {
+		t.Fatalf("Could not load state: %v", err)
+	}
+	if len(loaded.Bookmarks) != 1 ||
+		loaded.Bookmarks[0].Name != "Candidate slot" ||
+		!loaded.Bookmarks[0].Time.Equal(state.Bookmarks[0].Time) {
+		t.Errorf("Expected %v, but got %v", state.Bookmarks, loaded.Bookmarks)
+	}
+}
+
+func TestCorruptStateFile(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.toml")
+	corrupt := []byte("bookmarks = [[[ not toml")
+	if err := os.WriteFile(stateFile, corrupt, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path, state := openStateFile(stateFile)
+	if state == nil || len(state.Bookmarks) != 0 {
+		t.Errorf("Expected an empty state from a corrupt file, but got %v", state)
+	}
+	if path != "" {
+		t.Errorf("Expected no state file to save to, but got %q", path)
+	}
+
+	m := model{stateFile: path}
+	if err := m.addBookmark("Kept in memory"); err != nil || len(m.bookmarks) != 1 {
+		t.Errorf("Expected a bookmark in memory, but got %v, %v", m.bookmarks, err)
+	}
+	if data, _ := os.ReadFile(stateFile); string(data) != string(corrupt) {
+		t.Errorf("Expected the corrupt state file to be left alone, but got %q", data)
+	}
+}
+
+func TestUpdateBookmarks(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.toml")
+	m := model{
+		zones:     DefaultZones,
+		keymaps:   DefaultKeymaps,
+		clock:     *NewClockTime(utcMinuteAfterMidnightTime),
+		stateFile: stateFile,
+	}
+	key := func(msg tea.KeyMsg) {
+		if _, cmd := m.Update(msg); cmd != nil {
+			t.Fatalf("Expected nil Cmd, but got %v", cmd)
+		}
+	}
+	runes := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	// Bookmark two positions
+	key(runes("b"))
+	key(runes("first"))
+	key(enter)
+	key(runes("L"))
+	key(runes("b"))
+	key(runes("second"))
+	key(enter)
+
+	state, err := LoadState(stateFile)
+	if err != nil || len(state.Bookmarks) != 2 {
+		t.Fatalf("Expected 2 saved bookmarks, but got %v, %v", state, err)
+	}
+
+	// Jump back to the first one, then undo
+	key(runes("B"))
+	if !m.showBookmarks {
+		t.Fatal("Expected bookmarks overlay")
+	}
+	key(runes("j"))
+	key(runes("k"))
+	key(enter)
+	if m.showBookmarks || !m.clock.t.Equal(utcMinuteAfterMidnightTime) {
+		t.Errorf("Expected to jump to the first bookmark, but got %v", m.clock.t)
+	}
+	key(runes("u"))
+	if m.clock.t.Sub(utcMinuteAfterMidnightTime) != 24*time.Hour {
+		t.Errorf("Expected undo to go back to the second bookmark, but got %v", m.clock.t)
+	}
+
+	// Delete the second one, close with escape without quitting
+	key(runes("B"))
+	key(runes("j"))
+	key(tea.KeyMsg{Type: tea.KeyDelete})
+	key(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showBookmarks {
+		t.Error("Expected bookmarks overlay to close")
+	}
+
+	state, err = LoadState(stateFile)
+	if err != nil || len(state.Bookmarks) != 1 || state.Bookmarks[0].Name != "first" {
+		t.Errorf("Expected only the first bookmark to remain, but got %v, %v", state, err)
+	}
+}
@@ -0,0 +1,179 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Zone of the calendar overlay: the highlighted one, or else the first.
+func (m *model) calendarZone() *Zone {
+	if m.highlighted > 0 && m.highlighted <= len(m.zones) {
+		return m.zones[m.highlighted-1]
+	}
+	return m.zones[0]
+}
+
+// Open the calendar overlay on the clock's date in its zone.
+func (m *model) openCalendar() {
+	t := m.calendarZone().currentTime(m.clock.t)
+	m.calendarDate = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	m.showCalendar = true
+}
+
+// The clock's time of day, on the date selected in the calendar.
+func (m *model) calendarTime() time.Time {
+	t := m.calendarZone().currentTime(m.clock.t)
+	d := m.calendarDate
+	return time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// Handle keys of the calendar overlay: move the selected date by days,
+// weeks, months or years, pick it with enter, or close. Returns false for
+// keys it ignores.
+func (m *model) updateCalendar(msg tea.KeyMsg) bool {
+	key := msg.String()
+	days, months, years := 0, 0, 0
+	switch {
+	case match(key, m.keymaps.PrevHour) || match(key, m.keymaps.PrevDay):
+		days = -1
+	case match(key, m.keymaps.NextHour) || match(key, m.keymaps.NextDay):
+		days = 1
+	case match(key, m.keymaps.PrevLine) || match(key, m.keymaps.PrevWeek):
+		days = -7
+	case match(key, m.keymaps.NextLine) || match(key, m.keymaps.NextWeek):
+		days = 7
+	case match(key, m.keymaps.PrevMonth):
+		months = -1
+	case match(key, m.keymaps.NextMonth):
+		months = 1
+	case match(key, m.keymaps.PrevYear):
+		years = -1
+	case match(key, m.keymaps.NextYear):
+		years = 1
+
+	case key == "enter":
+		if t := m.calendarTime(); !t.Equal(m.clock.t) {
+			m.history.Push(m.clock)
+			m.clock = *NewClockTime(t.In(m.clock.t.Location()))
+		}
+		m.showCalendar = false
+
+	case key == "esc" || match(key, m.keymaps.Calendar):
+		m.showCalendar = false
+
+	default:
+		return false
+	}
+	m.calendarDate = m.calendarDate.AddDate(years, months, days)
+	return true
+}
+
+// Initials of the days of the week, from Monday, in the locale.
+func weekdayInitials() []string {
+	initials := []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}
+	if locale != nil && locale.ShortDays != nil {
+		for i := range initials {
+			initials[i] = strings.TrimSuffix(locale.ShortDays[(i+1)%7], ".")
+		}
+	}
+	return initials
+}
+
+// Whether the home zone, the first one, is on the previous (-1), same (0)
+// or next day (1) when it is `t` in another zone. Zones on either side of
+// the date line can be more than a day apart, up to 2 days.
+func homeDayShift(home *Zone, t time.Time) int {
+	h := home.currentTime(t)
+	homeDate := time.Date(h.Year(), h.Month(), h.Day(), 0, 0, 0, 0, time.UTC)
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int(homeDate.Sub(date).Hours()) / 24
+}
+
+// Arrows marking days on another date in the home zone.
+var dayShiftArrows = map[int]string{-2: "«", -1: "←", 0: " ", 1: "→", 2: "»"}
+
+// Render the calendar overlay: the month of the selected date in the
+// calendar's zone, with ISO week numbers. The selected date is marked >,
+// today *, and arrows mark the days when, at the clock's time of day, it
+// is the previous or next day in the home zone, or two days off.
+func calendarPanel(m *model) string {
+	zone := m.calendarZone()
+	home := m.zones[0]
+	selected := m.calendarTime()
+	today := zone.currentTime(time.Now())
+	first := time.Date(selected.Year(), selected.Month(), 1, selected.Hour(), selected.Minute(), 0, 0, zone.Loc)
+
+	s := strings.Builder{}
+	title := fmt.Sprintf("%s in %s, at %s", formatLocal(first, "January 2006"), zone.Name, selected.Format("15:04 MST"))
+	s.WriteString(fmt.Sprintf("  %s\n", normalTextStyle(title)))
+	days := "Wk"
+	for _, initials := range weekdayInitials() {
+		days += "   " + fitWidth(initials, 2)
+	}
+	s.WriteString(fmt.Sprintf("  %s\n", dateTimeStyle(days)))
+
+	// Start on the Monday of the first week of the month
+	day := first.AddDate(0, 0, -((int(first.Weekday()) + 6) % 7))
+	twoDays := false
+	for day.Month() == first.Month() || day.Before(first) {
+		_, week := day.ISOWeek()
+		s.WriteString(fmt.Sprintf("  %s", dateTimeStyle(fmt.Sprintf("%02d", week))))
+		for i := 0; i < 7; i++ {
+			cell := "    "
+			if day.Month() == first.Month() {
+				marker := " "
+				switch {
+				case day.YearDay() == selected.YearDay():
+					marker = ">"
+				case day.Year() == today.Year() && day.YearDay() == today.YearDay():
+					marker = "*"
+				}
+				arrow := " "
+				if home != zone {
+					shift := homeDayShift(home, day)
+					arrow = dayShiftArrows[shift]
+					twoDays = twoDays || shift == -2 || shift == 2
+				}
+				cell = fmt.Sprintf("%s%02d%s", marker, day.Day(), arrow)
+			}
+			switch {
+			case day.Month() == first.Month() && day.YearDay() == selected.YearDay():
+				s.WriteString(" " + highlightStyle(cell).String())
+			default:
+				s.WriteString(" " + normalTextStyle(cell).String())
+			}
+			day = time.Date(day.Year(), day.Month(), day.Day()+1, day.Hour(), day.Minute(), 0, 0, zone.Loc)
+		}
+		s.WriteString("\n")
+	}
+
+	legend := "> selected, * today"
+	if home != zone {
+		legend += fmt.Sprintf(", ←/→ previous/next day in %s", home.Name)
+	}
+	if twoDays {
+		legend += ", «/» two days"
+	}
+	s.WriteString(fmt.Sprintf("  %s\n", dateTimeStyle(legend)))
+	s.WriteString(fmt.Sprintf("  %s\n", dateTimeStyle("arrows: move, enter: pick date, esc: close")))
+	return s.String()
+}
@@ -2,6 +2,11 @@ package main
 
 import "time"
 
+// ClockJumpThreshold is the largest difference tolerated between the wall
+// clock and the monotonic clock between two ticks, before we consider that
+// the system time jumped (suspend/resume, NTP step, manual change...)
+const ClockJumpThreshold = 2 * time.Second
+
 // Clock keeps track of the current time.
 type Clock struct {
 	t time.Time
@@ -50,7 +55,150 @@ func (c *Clock) AddMinutes(n int) {
 	c.isRealTime = false
 }
 
+// AddMinuteSteps moves n steps of `step` minutes, landing on multiples of
+// `step` past the hour. With one minute steps, the seconds are kept.
+func (c *Clock) AddMinuteSteps(step int, n int) {
+	if step <= 1 {
+		c.AddMinutes(n)
+		return
+	}
+	stepDuration := time.Minute * time.Duration(step)
+	hour := startOfHour(c.t)
+	aligned := hour.Add(c.t.Sub(hour).Truncate(stepDuration))
+	if n < 0 && !aligned.Equal(c.t) {
+		// Moving back from between two steps first lands on the earlier one.
+		n++
+	}
+	c.t = aligned.Add(stepDuration * time.Duration(n))
+	c.isRealTime = false
+}
+
+// Snap moves to the nearest multiple of `step` minutes past the hour.
+func (c *Clock) Snap(step int) {
+	hour := startOfHour(c.t)
+	c.t = hour.Add(c.t.Sub(hour).Round(time.Minute * time.Duration(step)))
+	c.isRealTime = false
+}
+
 // Get the wrapped time.Time struct
 func (c *Clock) Time() time.Time {
 	return c.t
 }
+
+// WallClockDrift returns how much more the wall clock moved than the
+// monotonic clock between two readings of time.Now(). It is zero when
+// either reading has no monotonic clock.
+func WallClockDrift(previous, current time.Time) time.Duration {
+	elapsed := current.Sub(previous)
+	wallElapsed := current.Round(0).Sub(previous.Round(0))
+	return wallElapsed - elapsed
+}
+
+// ClockJumped reports whether the wall clock jumped between two readings
+// of time.Now().
+func ClockJumped(previous, current time.Time) bool {
+	if previous.IsZero() {
+		return false
+	}
+	return IsClockJump(WallClockDrift(previous, current))
+}
+
+// IsClockJump reports whether a drift of the wall clock from the
+// monotonic clock is a jump, rather than the usual slewing.
+func IsClockJump(drift time.Duration) bool {
+	return drift.Abs() > ClockJumpThreshold
+}
+
+// AddMonths adds n months to the current date, keeping the time of day.
+// Days past the end of the target month are clamped to its last day, so
+// that January 31st plus one month is the end of February.
+func (c *Clock) AddMonths(n int) {
+	c.t = addMonthsClamped(c.t, n)
+	c.isRealTime = false
+}
+
+// AddYears adds n years to the current date, keeping the time of day.
+// February 29th moves to February 28th on other years.
+func (c *Clock) AddYears(n int) {
+	c.t = addMonthsClamped(c.t, 12*n)
+	c.isRealTime = false
+}
+
+// StartOfDay moves to the first instant of the current day.
+func (c *Clock) StartOfDay() {
+	c.t = startOfDay(c.t, 0)
+	c.isRealTime = false
+}
+
+// StartOfWeek moves to the first instant of the current week, starting on
+// Monday.
+func (c *Clock) StartOfWeek() {
+	daysSinceMonday := (int(c.t.Weekday()) + 6) % 7
+	c.t = startOfDay(c.t, -daysSinceMonday)
+	c.isRealTime = false
+}
+
+// NextWeekday moves to the start of the next business day, from Monday
+// to Friday.
+func (c *Clock) NextWeekday() {
+	t := startOfDay(c.t, 1)
+	for !isWeekday(t) {
+		t = startOfDay(t, 1)
+	}
+	c.t = t
+	c.isRealTime = false
+}
+
+// FirstWeekday moves to the start of the first business day of the
+// current month.
+func (c *Clock) FirstWeekday() {
+	t := startOfDay(c.t, 1-c.t.Day())
+	for !isWeekday(t) {
+		t = startOfDay(t, 1)
+	}
+	c.t = t
+	c.isRealTime = false
+}
+
+// Add n months to t, clamping the day to the end of the target month.
+func addMonthsClamped(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	lastDay := time.Date(year, month+time.Month(n)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	return time.Date(
+		year,
+		month+time.Month(n),
+		min(day, lastDay),
+		t.Hour(),
+		t.Minute(),
+		t.Second(),
+		t.Nanosecond(),
+		t.Location(),
+	)
+}
+
+// The first instant of the wall clock hour of t. Counting back in elapsed
+// time keeps the hour right for zones with fractional offsets.
+func startOfHour(t time.Time) time.Time {
+	sinceHour := time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second +
+		time.Duration(t.Nanosecond())
+	return t.Add(-sinceHour)
+}
+
+// The first instant of the day, n days after the day of t. When midnight
+// is skipped by a DST transition, the day starts right after it.
+func startOfDay(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	noon := time.Date(year, month, day+n, 12, 0, 0, 0, t.Location())
+	midnight := time.Date(noon.Year(), noon.Month(), noon.Day(), 0, 0, 0, 0, t.Location())
+	if midnight.Day() != noon.Day() {
+		// time.Date moved the skipped midnight back to the previous day.
+		_, end := midnight.ZoneBounds()
+		return end
+	}
+	return midnight
+}
+
+func isWeekday(t time.Time) bool {
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
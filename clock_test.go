@@ -59,3 +59,227 @@ func TestClockUnixTimestamp(t *testing.T) {
 		t.Error("NewClockUnixTimestamp() shouldn’t be real time")
 	}
 }
+
+func TestClockJumped(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name     string
+		previous time.Time
+		current  time.Time
+		jumped   bool
+	}{
+		{"First tick", time.Time{}, start, false},
+		{"Regular tick", start, start.Add(time.Minute), false},
+		{"No monotonic clock", start.Round(0), start.Add(time.Hour).Round(0), false},
+	}
+	for _, test := range tests {
+		if jumped := ClockJumped(test.previous, test.current); jumped != test.jumped {
+			t.Errorf("%s: expected ClockJumped() to be %v, but got %v", test.name, test.jumped, jumped)
+		}
+	}
+
+	// Readings of time.Now() can't drift in a test: check the drifts that
+	// suspends and NTP steps cause.
+	drifts := []struct {
+		name   string
+		drift  time.Duration
+		jumped bool
+	}{
+		{"NTP slewing", 500 * time.Millisecond, false},
+		{"Resumed after an hour of suspend", time.Hour, true},
+		{"NTP step forward", 5 * time.Second, true},
+		{"NTP step back", -30 * time.Second, true},
+	}
+	for _, test := range drifts {
+		if jumped := IsClockJump(test.drift); jumped != test.jumped {
+			t.Errorf("%s: expected IsClockJump(%v) to be %v, but got %v", test.name, test.drift, test.jumped, jumped)
+		}
+	}
+}
+
+func TestClockCalendarArithmetic(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cuba, err := time.LoadLocation("Cuba")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		move     func(c *Clock)
+		expected string
+	}{
+		{
+			"Next month keeps the time of day across DST",
+			time.Date(2024, time.March, 15, 12, 30, 0, 0, paris),
+			func(c *Clock) { c.AddMonths(1) },
+			"2024-04-15T12:30:00+02:00",
+		},
+		{
+			"Next month clamps to the end of February",
+			time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC),
+			func(c *Clock) { c.AddMonths(1) },
+			"2024-02-29T09:00:00Z",
+		},
+		{
+			"Previous month clamps to the end of November",
+			time.Date(2023, time.December, 31, 9, 0, 0, 0, time.UTC),
+			func(c *Clock) { c.AddMonths(-1) },
+			"2023-11-30T09:00:00Z",
+		},
+		{
+			"Months across years",
+			time.Date(2023, time.November, 30, 9, 0, 0, 0, time.UTC),
+			func(c *Clock) { c.AddMonths(14) },
+			"2025-01-30T09:00:00Z",
+		},
+		{
+			"Next year clamps leap days",
+			time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC),
+			func(c *Clock) { c.AddYears(1) },
+			"2025-02-28T09:00:00Z",
+		},
+		{
+			"Start of day on a 23-hour day",
+			time.Date(2024, time.March, 31, 18, 0, 0, 0, paris),
+			func(c *Clock) { c.StartOfDay() },
+			"2024-03-31T00:00:00+01:00",
+		},
+		{
+			"Start of day when midnight is skipped",
+			time.Date(2017, time.March, 12, 18, 0, 0, 0, cuba),
+			func(c *Clock) { c.StartOfDay() },
+			"2017-03-12T01:00:00-04:00",
+		},
+		{
+			"Start of day when midnight happens twice",
+			time.Date(2017, time.November, 5, 18, 0, 0, 0, cuba),
+			func(c *Clock) { c.StartOfDay() },
+			"2017-11-05T00:00:00-04:00",
+		},
+		{
+			"Start of week across DST",
+			time.Date(2024, time.April, 2, 18, 0, 0, 0, paris),
+			func(c *Clock) { c.StartOfWeek() },
+			"2024-04-01T00:00:00+02:00",
+		},
+		{
+			"Start of week on Sunday",
+			time.Date(2024, time.March, 31, 18, 0, 0, 0, paris),
+			func(c *Clock) { c.StartOfWeek() },
+			"2024-03-25T00:00:00+01:00",
+		},
+		{
+			"Next weekday after Friday",
+			time.Date(2024, time.March, 29, 18, 0, 0, 0, paris),
+			func(c *Clock) { c.NextWeekday() },
+			"2024-04-01T00:00:00+02:00",
+		},
+		{
+			"Next weekday on Monday",
+			time.Date(2024, time.April, 1, 8, 0, 0, 0, paris),
+			func(c *Clock) { c.NextWeekday() },
+			"2024-04-02T00:00:00+02:00",
+		},
+		{
+			"First weekday of a month starting on Saturday",
+			time.Date(2024, time.June, 20, 8, 0, 0, 0, paris),
+			func(c *Clock) { c.FirstWeekday() },
+			"2024-06-03T00:00:00+02:00",
+		},
+	}
+
+	for _, test := range tests {
+		clock := NewClockTime(test.start)
+		test.move(clock)
+		observed := clock.Time().Format(time.RFC3339)
+		if observed != test.expected {
+			t.Errorf("%s: expected %s, but got %s", test.name, test.expected, observed)
+		}
+		if clock.isRealTime {
+			t.Errorf("%s: clock shouldn’t be real time", test.name)
+		}
+	}
+}
+
+func TestClockMinuteSteps(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		move     func(c *Clock)
+		expected string
+	}{
+		{
+			"One minute steps keep the seconds",
+			time.Date(2024, time.May, 2, 10, 7, 30, 0, time.UTC),
+			func(c *Clock) { c.AddMinuteSteps(1, -1) },
+			"2024-05-02T10:06:30Z",
+		},
+		{
+			"Next step from between two steps",
+			time.Date(2024, time.May, 2, 10, 7, 30, 0, time.UTC),
+			func(c *Clock) { c.AddMinuteSteps(15, 1) },
+			"2024-05-02T10:15:00Z",
+		},
+		{
+			"Previous step from between two steps",
+			time.Date(2024, time.May, 2, 10, 7, 30, 0, time.UTC),
+			func(c *Clock) { c.AddMinuteSteps(15, -1) },
+			"2024-05-02T10:00:00Z",
+		},
+		{
+			"Previous step from a step",
+			time.Date(2024, time.May, 2, 10, 0, 0, 0, time.UTC),
+			func(c *Clock) { c.AddMinuteSteps(30, -1) },
+			"2024-05-02T09:30:00Z",
+		},
+		{
+			"Steps across hours",
+			time.Date(2024, time.May, 2, 10, 50, 0, 0, time.UTC),
+			func(c *Clock) { c.AddMinuteSteps(10, 2) },
+			"2024-05-02T11:10:00Z",
+		},
+		{
+			"Steps follow wall clock minutes with fractional offsets",
+			time.Date(2024, time.May, 2, 10, 7, 0, 0, kolkata),
+			func(c *Clock) { c.AddMinuteSteps(30, 1) },
+			"2024-05-02T10:30:00+05:30",
+		},
+		{
+			"Snap down to the nearest quarter",
+			time.Date(2024, time.May, 2, 10, 7, 29, 0, time.UTC),
+			func(c *Clock) { c.Snap(15) },
+			"2024-05-02T10:00:00Z",
+		},
+		{
+			"Snap up to the nearest quarter",
+			time.Date(2024, time.May, 2, 10, 7, 30, 0, time.UTC),
+			func(c *Clock) { c.Snap(15) },
+			"2024-05-02T10:15:00Z",
+		},
+		{
+			"Snap up to the next hour",
+			time.Date(2024, time.May, 2, 10, 48, 0, 0, kolkata),
+			func(c *Clock) { c.Snap(30) },
+			"2024-05-02T11:00:00+05:30",
+		},
+	}
+
+	for _, test := range tests {
+		clock := NewClockTime(test.start)
+		test.move(clock)
+		observed := clock.Time().Format(time.RFC3339)
+		if observed != test.expected {
+			t.Errorf("%s: expected %s, but got %s", test.name, test.expected, observed)
+		}
+	}
+}
@@ -25,56 +25,136 @@ import (
 
 // Keymaps represents the key mappings in the TOML file
 type Keymaps struct {
-	PrevMinute []string
-	NextMinute []string
-	ZeroMinute []string
-	PrevHour   []string
-	NextHour   []string
-	PrevDay    []string
-	NextDay    []string
-	PrevWeek   []string
-	NextWeek   []string
-	PrevLine   []string
-	NextLine   []string
-	PrevFStyle []string
-	NextFStyle []string
-	PrevZStyle []string
-	NextZStyle []string
-	ToggleDate []string
-	OpenWeb    []string
-	Now        []string
-	Help       []string
-	Quit       []string
+	PrevMinute     []string
+	NextMinute     []string
+	ZeroMinute     []string
+	SnapQuarter    []string
+	SnapHalf       []string
+	PrevHour       []string
+	NextHour       []string
+	PrevDay        []string
+	NextDay        []string
+	PrevWeek       []string
+	NextWeek       []string
+	PrevMonth      []string
+	NextMonth      []string
+	PrevYear       []string
+	NextYear       []string
+	StartOfDay     []string
+	StartOfWeek    []string
+	NextWeekday    []string
+	FirstWeekday   []string
+	Undo           []string
+	Redo           []string
+	PrevLine       []string
+	NextLine       []string
+	PrevFStyle     []string
+	NextFStyle     []string
+	PrevZStyle     []string
+	NextZStyle     []string
+	ToggleDate     []string
+	OpenWeb        []string
+	Now            []string
+	AddBookmark    []string
+	Bookmarks      []string
+	BigClock       []string
+	Calendar       []string
+	AddAlarm       []string
+	PrevTransition []string
+	NextTransition []string
+	ToggleGrid     []string
+	ToggleMilitary []string
+	ToggleSun      []string
+	NextLayout     []string
+	ToggleDST      []string
+	Help           []string
+	Quit           []string
 }
 
 // Config stores app configuration
 type Config struct {
-	Zones   []*Zone
-	Keymaps Keymaps
+	Zones        []*Zone
+	Deadlines    []*Deadline
+	Alarms       []*Alarm
+	AlarmCommand string
+	MinuteStep   int
+	Header       string
+	HideHeader   bool
+	DisableMouse bool
+	Accessible   bool
+	Profile      string
+	Profiles     map[string]*Profile
+	Theme        *Theme
+	Bands        []Band
+	Holidays     []string
+	Formats      []Format
+	Locale       *Locale
+	Military     *bool // 24-hour time, unless nil for the locale's preference
+	Keymaps      Keymaps
+}
+
+// Profile is a named set of settings from the config file, overriding
+// the top-level ones when selected with TZ_PROFILE.
+type Profile struct {
+	Zones      []*Zone
+	MinuteStep int
+}
+
+// Minute steps divide an hour evenly, so that stepping lands on the same
+// minutes every hour.
+func checkMinuteStep(step int) error {
+	if step < 0 || step > 60 || (step > 0 && 60%step != 0) {
+		return fmt.Errorf("Invalid minute_step %d: use a divisor of 60, like 5, 10, 15 or 30", step)
+	}
+	return nil
 }
 
 // Function to provide default values for the Config struct
 var DefaultKeymaps = Keymaps{
-	PrevMinute: []string{"-"},
-	NextMinute: []string{"+"},
-	ZeroMinute: []string{"0"},
-	PrevHour:   []string{"h", "left"},
-	NextHour:   []string{"l", "right"},
-	PrevDay:    []string{"H", "shift+left", "pgup", "shift+up", "ctrl+u"},
-	NextDay:    []string{"L", "shift+right", "pgdown", "shift+down", "ctrl+d"},
-	PrevWeek:   []string{"p", "ctrl+left", "shift+pgup", "ctrl+b"},
-	NextWeek:   []string{"n", "ctrl+right", "shift+pgdown", "ctrl+f"},
-	PrevLine:   []string{"k", "up"},
-	NextLine:   []string{"j", "down"},
-	PrevFStyle: []string{"F"},
-	NextFStyle: []string{"f"},
-	PrevZStyle: []string{"Z"},
-	NextZStyle: []string{"z"},
-	ToggleDate: []string{"d"},
-	OpenWeb:    []string{"o"},
-	Now:        []string{"t"},
-	Help:       []string{"?"},
-	Quit:       []string{"q", "ctrl+c", "esc"},
+	PrevMinute:     []string{"-"},
+	NextMinute:     []string{"+"},
+	ZeroMinute:     []string{"0"},
+	SnapQuarter:    []string{"4"},
+	SnapHalf:       []string{"2"},
+	PrevHour:       []string{"h", "left"},
+	NextHour:       []string{"l", "right"},
+	PrevDay:        []string{"H", "shift+left", "pgup", "shift+up", "ctrl+u"},
+	NextDay:        []string{"L", "shift+right", "pgdown", "shift+down", "ctrl+d"},
+	PrevWeek:       []string{"p", "ctrl+left", "shift+pgup", "ctrl+b"},
+	NextWeek:       []string{"n", "ctrl+right", "shift+pgdown", "ctrl+f"},
+	PrevMonth:      []string{"{"},
+	NextMonth:      []string{"}"},
+	PrevYear:       []string{"("},
+	NextYear:       []string{")"},
+	StartOfDay:     []string{"^"},
+	StartOfWeek:    []string{"W"},
+	NextWeekday:    []string{"w"},
+	FirstWeekday:   []string{"g"},
+	Undo:           []string{"u"},
+	Redo:           []string{"U", "ctrl+r"},
+	PrevLine:       []string{"k", "up"},
+	NextLine:       []string{"j", "down"},
+	PrevFStyle:     []string{"F"},
+	NextFStyle:     []string{"f"},
+	PrevZStyle:     []string{"Z"},
+	NextZStyle:     []string{"z"},
+	ToggleDate:     []string{"d"},
+	OpenWeb:        []string{"o"},
+	Now:            []string{"t"},
+	AddBookmark:    []string{"b"},
+	Bookmarks:      []string{"B"},
+	BigClock:       []string{"C"},
+	Calendar:       []string{"c"},
+	AddAlarm:       []string{"a"},
+	PrevTransition: []string{"["},
+	NextTransition: []string{"]"},
+	ToggleGrid:     []string{"G"},
+	ToggleMilitary: []string{"m"},
+	ToggleSun:      []string{"s"},
+	NextLayout:     []string{"v"},
+	ToggleDST:      []string{"D"},
+	Help:           []string{"?"},
+	Quit:           []string{"q", "ctrl+c", "esc"},
 }
 
 func LoadDefaultConfig(tzConfigs []string) (*Config, error) {
@@ -102,8 +182,27 @@ func LoadConfig(tomlFile string, tzConfigs []string) (*Config, error) {
 
 	// Merge configs, with envConfig taking precedence
 	mergedConfig := Config{
-		Zones:   []*Zone{DefaultZones[0]},
-		Keymaps: DefaultKeymaps,
+		Zones:      []*Zone{DefaultZones[0]},
+		MinuteStep: 1,
+		Keymaps:    DefaultKeymaps,
+	}
+	if fileConfig.MinuteStep > 0 {
+		mergedConfig.MinuteStep = fileConfig.MinuteStep
+	}
+
+	// Apply the selected profile over the config file
+	if envConfig.Profile != "" {
+		profile, ok := fileConfig.Profiles[envConfig.Profile]
+		if !ok {
+			return nil, fmt.Errorf("Unknown profile %s", envConfig.Profile)
+		}
+		mergedConfig.Profile = envConfig.Profile
+		if len(profile.Zones) > 0 {
+			fileConfig.Zones = profile.Zones
+		}
+		if profile.MinuteStep > 0 {
+			mergedConfig.MinuteStep = profile.MinuteStep
+		}
 	}
 
 	// Merge Zones
@@ -121,6 +220,39 @@ func LoadConfig(tomlFile string, tzConfigs []string) (*Config, error) {
 	logger.Printf("Env zones: %s", envConfig.Zones)
 	logger.Printf("Merged zones: %s", mergedConfig.Zones)
 
+	// Deadlines, alarms, themes, bands, holidays and formats only come
+	// from the config file
+	mergedConfig.Theme = fileConfig.Theme
+	if mergedConfig.Theme == nil {
+		mergedConfig.Theme, _ = LoadTheme("")
+	}
+	mergedConfig.Bands = fileConfig.Bands
+	mergedConfig.Holidays = fileConfig.Holidays
+	mergedConfig.Formats = fileConfig.Formats
+
+	// Use the locale of the config file, or else the environment's
+	mergedConfig.Locale = fileConfig.Locale
+	if mergedConfig.Locale == nil {
+		var err error
+		envLocale := LocaleFromEnv()
+		if mergedConfig.Locale, err = LoadLocale(envLocale); err != nil {
+			// Without translations, still follow the locale's clock
+			logger.Printf("%s, using English", err)
+			mergedConfig.Locale = EnglishFor(envLocale)
+		}
+	}
+	mergedConfig.Military = fileConfig.Military
+	if mergedConfig.Military == nil {
+		mergedConfig.Military = &mergedConfig.Locale.Military
+	}
+	mergedConfig.Deadlines = fileConfig.Deadlines
+	mergedConfig.Alarms = fileConfig.Alarms
+	mergedConfig.AlarmCommand = fileConfig.AlarmCommand
+	mergedConfig.DisableMouse = fileConfig.DisableMouse
+	mergedConfig.Accessible = fileConfig.Accessible
+	mergedConfig.Header = fileConfig.Header
+	mergedConfig.HideHeader = fileConfig.HideHeader
+
 	// Merge Keymaps
 	if len(fileConfig.Keymaps.PrevMinute) > 0 {
 		mergedConfig.Keymaps.PrevMinute = fileConfig.Keymaps.PrevMinute
@@ -134,6 +266,14 @@ func LoadConfig(tomlFile string, tzConfigs []string) (*Config, error) {
 		mergedConfig.Keymaps.ZeroMinute = fileConfig.Keymaps.ZeroMinute
 	}
 
+	if len(fileConfig.Keymaps.SnapQuarter) > 0 {
+		mergedConfig.Keymaps.SnapQuarter = fileConfig.Keymaps.SnapQuarter
+	}
+
+	if len(fileConfig.Keymaps.SnapHalf) > 0 {
+		mergedConfig.Keymaps.SnapHalf = fileConfig.Keymaps.SnapHalf
+	}
+
 	if len(fileConfig.Keymaps.PrevHour) > 0 {
 		mergedConfig.Keymaps.PrevHour = fileConfig.Keymaps.PrevHour
 	}
@@ -158,6 +298,46 @@ func LoadConfig(tomlFile string, tzConfigs []string) (*Config, error) {
 		mergedConfig.Keymaps.NextWeek = fileConfig.Keymaps.NextWeek
 	}
 
+	if len(fileConfig.Keymaps.PrevMonth) > 0 {
+		mergedConfig.Keymaps.PrevMonth = fileConfig.Keymaps.PrevMonth
+	}
+
+	if len(fileConfig.Keymaps.NextMonth) > 0 {
+		mergedConfig.Keymaps.NextMonth = fileConfig.Keymaps.NextMonth
+	}
+
+	if len(fileConfig.Keymaps.PrevYear) > 0 {
+		mergedConfig.Keymaps.PrevYear = fileConfig.Keymaps.PrevYear
+	}
+
+	if len(fileConfig.Keymaps.NextYear) > 0 {
+		mergedConfig.Keymaps.NextYear = fileConfig.Keymaps.NextYear
+	}
+
+	if len(fileConfig.Keymaps.StartOfDay) > 0 {
+		mergedConfig.Keymaps.StartOfDay = fileConfig.Keymaps.StartOfDay
+	}
+
+	if len(fileConfig.Keymaps.StartOfWeek) > 0 {
+		mergedConfig.Keymaps.StartOfWeek = fileConfig.Keymaps.StartOfWeek
+	}
+
+	if len(fileConfig.Keymaps.NextWeekday) > 0 {
+		mergedConfig.Keymaps.NextWeekday = fileConfig.Keymaps.NextWeekday
+	}
+
+	if len(fileConfig.Keymaps.FirstWeekday) > 0 {
+		mergedConfig.Keymaps.FirstWeekday = fileConfig.Keymaps.FirstWeekday
+	}
+
+	if len(fileConfig.Keymaps.Undo) > 0 {
+		mergedConfig.Keymaps.Undo = fileConfig.Keymaps.Undo
+	}
+
+	if len(fileConfig.Keymaps.Redo) > 0 {
+		mergedConfig.Keymaps.Redo = fileConfig.Keymaps.Redo
+	}
+
 	if len(fileConfig.Keymaps.PrevLine) > 0 {
 		mergedConfig.Keymaps.PrevLine = fileConfig.Keymaps.PrevLine
 	}
@@ -194,6 +374,54 @@ func LoadConfig(tomlFile string, tzConfigs []string) (*Config, error) {
 		mergedConfig.Keymaps.Now = fileConfig.Keymaps.Now
 	}
 
+	if len(fileConfig.Keymaps.AddBookmark) > 0 {
+		mergedConfig.Keymaps.AddBookmark = fileConfig.Keymaps.AddBookmark
+	}
+
+	if len(fileConfig.Keymaps.Bookmarks) > 0 {
+		mergedConfig.Keymaps.Bookmarks = fileConfig.Keymaps.Bookmarks
+	}
+
+	if len(fileConfig.Keymaps.BigClock) > 0 {
+		mergedConfig.Keymaps.BigClock = fileConfig.Keymaps.BigClock
+	}
+
+	if len(fileConfig.Keymaps.Calendar) > 0 {
+		mergedConfig.Keymaps.Calendar = fileConfig.Keymaps.Calendar
+	}
+
+	if len(fileConfig.Keymaps.AddAlarm) > 0 {
+		mergedConfig.Keymaps.AddAlarm = fileConfig.Keymaps.AddAlarm
+	}
+
+	if len(fileConfig.Keymaps.PrevTransition) > 0 {
+		mergedConfig.Keymaps.PrevTransition = fileConfig.Keymaps.PrevTransition
+	}
+
+	if len(fileConfig.Keymaps.NextTransition) > 0 {
+		mergedConfig.Keymaps.NextTransition = fileConfig.Keymaps.NextTransition
+	}
+
+	if len(fileConfig.Keymaps.ToggleGrid) > 0 {
+		mergedConfig.Keymaps.ToggleGrid = fileConfig.Keymaps.ToggleGrid
+	}
+
+	if len(fileConfig.Keymaps.ToggleMilitary) > 0 {
+		mergedConfig.Keymaps.ToggleMilitary = fileConfig.Keymaps.ToggleMilitary
+	}
+
+	if len(fileConfig.Keymaps.ToggleSun) > 0 {
+		mergedConfig.Keymaps.ToggleSun = fileConfig.Keymaps.ToggleSun
+	}
+
+	if len(fileConfig.Keymaps.NextLayout) > 0 {
+		mergedConfig.Keymaps.NextLayout = fileConfig.Keymaps.NextLayout
+	}
+
+	if len(fileConfig.Keymaps.ToggleDST) > 0 {
+		mergedConfig.Keymaps.ToggleDST = fileConfig.Keymaps.ToggleDST
+	}
+
 	if len(fileConfig.Keymaps.Help) > 0 {
 		mergedConfig.Keymaps.Help = fileConfig.Keymaps.Help
 	}
@@ -206,12 +434,24 @@ func LoadConfig(tomlFile string, tzConfigs []string) (*Config, error) {
 		mergedConfig.Keymaps.PrevMinute,
 		mergedConfig.Keymaps.NextMinute,
 		mergedConfig.Keymaps.ZeroMinute,
+		mergedConfig.Keymaps.SnapQuarter,
+		mergedConfig.Keymaps.SnapHalf,
 		mergedConfig.Keymaps.PrevHour,
 		mergedConfig.Keymaps.NextHour,
 		mergedConfig.Keymaps.PrevDay,
 		mergedConfig.Keymaps.NextDay,
 		mergedConfig.Keymaps.PrevWeek,
 		mergedConfig.Keymaps.NextWeek,
+		mergedConfig.Keymaps.PrevMonth,
+		mergedConfig.Keymaps.NextMonth,
+		mergedConfig.Keymaps.PrevYear,
+		mergedConfig.Keymaps.NextYear,
+		mergedConfig.Keymaps.StartOfDay,
+		mergedConfig.Keymaps.StartOfWeek,
+		mergedConfig.Keymaps.NextWeekday,
+		mergedConfig.Keymaps.FirstWeekday,
+		mergedConfig.Keymaps.Undo,
+		mergedConfig.Keymaps.Redo,
 		mergedConfig.Keymaps.PrevLine,
 		mergedConfig.Keymaps.NextLine,
 		mergedConfig.Keymaps.PrevFStyle,
@@ -221,6 +461,18 @@ func LoadConfig(tomlFile string, tzConfigs []string) (*Config, error) {
 		mergedConfig.Keymaps.ToggleDate,
 		mergedConfig.Keymaps.OpenWeb,
 		mergedConfig.Keymaps.Now,
+		mergedConfig.Keymaps.AddBookmark,
+		mergedConfig.Keymaps.Bookmarks,
+		mergedConfig.Keymaps.BigClock,
+		mergedConfig.Keymaps.Calendar,
+		mergedConfig.Keymaps.AddAlarm,
+		mergedConfig.Keymaps.PrevTransition,
+		mergedConfig.Keymaps.NextTransition,
+		mergedConfig.Keymaps.ToggleGrid,
+		mergedConfig.Keymaps.ToggleMilitary,
+		mergedConfig.Keymaps.ToggleSun,
+		mergedConfig.Keymaps.NextLayout,
+		mergedConfig.Keymaps.ToggleDST,
 		mergedConfig.Keymaps.Help,
 		mergedConfig.Keymaps.Quit,
 	}
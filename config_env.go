@@ -25,7 +25,7 @@ import (
 
 // LoadConfigEnv from environment
 func LoadConfigEnv(tzConfigs []string, now time.Time) (*Config, error) {
-	conf := Config{}
+	conf := Config{Profile: os.Getenv("TZ_PROFILE")}
 
 	if len(tzConfigs) == 0 {
 		tzList := os.Getenv("TZ_LIST")
@@ -61,7 +61,7 @@ func ReadZoneFromString(now time.Time, zoneConf string) (*Zone, error) {
 		name = names[1]
 	}
 
-	loc, err := time.LoadLocation(dbName)
+	loc, err := LoadLocation(dbName)
 	if err != nil {
 		return nil, fmt.Errorf("looking up zone %s: %w", dbName, err)
 	}
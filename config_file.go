@@ -11,59 +11,195 @@ import (
 
 // Config represents the entire TOML configuration
 type ConfigFile struct {
-	Header  string            `toml:"header"`
-	Zones   []ConfigFileZone  `toml:"zones"`
-	Keymaps ConfigFileKeymaps `toml:"keymaps"`
+	Header       *string                      `toml:"header"`
+	ZoneInfo     string                       `toml:"zoneinfo"`
+	Alarms       []string                     `toml:"alarms"`
+	AlarmCommand string                       `toml:"alarm_command"`
+	MinuteStep   int                          `toml:"minute_step"`
+	Mouse        *bool                        `toml:"mouse"`
+	Accessible   bool                         `toml:"accessible"`
+	Zones        []ConfigFileZone             `toml:"zones"`
+	Deadlines    []ConfigFileDeadline         `toml:"deadlines"`
+	Theme        ConfigFileTheme              `toml:"theme"`
+	Bands        []ConfigFileBand             `toml:"bands"`
+	Holidays     []string                     `toml:"holidays"`
+	Formats      []ConfigFileFormat           `toml:"formats"`
+	Locale       string                       `toml:"locale"`
+	Military     *bool                        `toml:"military"`
+	Keymaps      ConfigFileKeymaps            `toml:"keymaps"`
+	Profiles     map[string]ConfigFileProfile `toml:"profiles"`
 }
 
 // Zone represents a single zone entry in the TOML file
 type ConfigFileZone struct {
-	ID   string `toml:"id"`
+	ID        string           `toml:"id"`
+	Name      string           `toml:"name"`
+	Bands     []ConfigFileBand `toml:"bands"`
+	Holidays  []string         `toml:"holidays"`
+	Military  *bool            `toml:"military"`
+	Latitude  *float64         `toml:"latitude"`
+	Longitude *float64         `toml:"longitude"`
+}
+
+// Band represents a time of day of the timelines in the TOML file
+type ConfigFileBand struct {
+	Name   string `toml:"name"`
+	Start  int    `toml:"start"`
+	End    int    `toml:"end"`
+	Color  string `toml:"color"`
+	Symbol string `toml:"symbol"`
+	Kind   string `toml:"kind"`
+}
+
+// Deadline represents a single deadline entry in the TOML file
+type ConfigFileDeadline struct {
 	Name string `toml:"name"`
+	Time string `toml:"time"`
+	Zone string `toml:"zone"`
+}
+
+// Profile represents a named set of zones in the TOML file
+type ConfigFileProfile struct {
+	MinuteStep int              `toml:"minute_step"`
+	Zones      []ConfigFileZone `toml:"zones"`
+}
+
+// Format represents a named format of dates and times in the TOML file
+type ConfigFileFormat struct {
+	Name     string `toml:"name"`
+	Layout   string `toml:"layout"`
+	Strftime bool   `toml:"strftime"`
+}
+
+// Theme represents the colors of the timelines in the TOML file
+type ConfigFileTheme struct {
+	Name  string            `toml:"name"`
+	Dark  ConfigFilePalette `toml:"dark"`
+	Light ConfigFilePalette `toml:"light"`
+}
+
+// Palette represents the colors of a theme for a dark or light terminal in the TOML file
+type ConfigFilePalette struct {
+	Morning    string `toml:"morning"`
+	Day        string `toml:"day"`
+	Evening    string `toml:"evening"`
+	Night      string `toml:"night"`
+	CursorText string `toml:"cursor_text"`
+	Highlight  string `toml:"highlight"`
+	Dates      string `toml:"dates"`
+	DateTime   string `toml:"date_time"`
+	Text       string `toml:"text"`
+	Status     string `toml:"status"`
 }
 
 // Keymaps represents the key mappings in the TOML file
 type ConfigFileKeymaps struct {
-	PrevMinute []string `toml:"prev_minute"`
-	NextMinute []string `toml:"next_minute"`
-	ZeroMinute []string `toml:"zero_minute"`
-	PrevHour   []string `toml:"prev_hour"`
-	NextHour   []string `toml:"next_hour"`
-	PrevDay    []string `toml:"prev_day"`
-	NextDay    []string `toml:"next_day"`
-	PrevWeek   []string `toml:"prev_week"`
-	NextWeek   []string `toml:"next_week"`
-	PrevLine   []string `toml:"prev_line_select"`
-	NextLine   []string `toml:"next_line_select"`
-	PrevFStyle []string `toml:"prev_format_style"`
-	NextFStyle []string `toml:"next_format_style"`
-	PrevZStyle []string `toml:"prev_zone_style"`
-	NextZStyle []string `toml:"next_zone_style"`
-	ToggleDate []string `toml:"toggle_date"`
-	OpenWeb    []string `toml:"open_web"`
-	Now        []string `toml:"now"`
-	Help       []string `toml:"help"`
-	Quit       []string `toml:"quit"`
+	PrevMinute     []string `toml:"prev_minute"`
+	NextMinute     []string `toml:"next_minute"`
+	ZeroMinute     []string `toml:"zero_minute"`
+	SnapQuarter    []string `toml:"snap_quarter"`
+	SnapHalf       []string `toml:"snap_half"`
+	PrevHour       []string `toml:"prev_hour"`
+	NextHour       []string `toml:"next_hour"`
+	PrevDay        []string `toml:"prev_day"`
+	NextDay        []string `toml:"next_day"`
+	PrevWeek       []string `toml:"prev_week"`
+	NextWeek       []string `toml:"next_week"`
+	PrevMonth      []string `toml:"prev_month"`
+	NextMonth      []string `toml:"next_month"`
+	PrevYear       []string `toml:"prev_year"`
+	NextYear       []string `toml:"next_year"`
+	StartOfDay     []string `toml:"start_of_day"`
+	StartOfWeek    []string `toml:"start_of_week"`
+	NextWeekday    []string `toml:"next_weekday"`
+	FirstWeekday   []string `toml:"first_weekday"`
+	Undo           []string `toml:"undo"`
+	Redo           []string `toml:"redo"`
+	PrevLine       []string `toml:"prev_line_select"`
+	NextLine       []string `toml:"next_line_select"`
+	PrevFStyle     []string `toml:"prev_format_style"`
+	NextFStyle     []string `toml:"next_format_style"`
+	PrevZStyle     []string `toml:"prev_zone_style"`
+	NextZStyle     []string `toml:"next_zone_style"`
+	ToggleDate     []string `toml:"toggle_date"`
+	OpenWeb        []string `toml:"open_web"`
+	Now            []string `toml:"now"`
+	AddBookmark    []string `toml:"add_bookmark"`
+	Bookmarks      []string `toml:"bookmarks"`
+	BigClock       []string `toml:"big_clock"`
+	Calendar       []string `toml:"calendar"`
+	AddAlarm       []string `toml:"add_alarm"`
+	PrevTransition []string `toml:"prev_transition"`
+	NextTransition []string `toml:"next_transition"`
+	ToggleGrid     []string `toml:"toggle_grid"`
+	ToggleMilitary []string `toml:"toggle_military"`
+	ToggleSun      []string `toml:"toggle_sun"`
+	NextLayout     []string `toml:"next_layout"`
+	ToggleDST      []string `toml:"toggle_dst"`
+	Help           []string `toml:"help"`
+	Quit           []string `toml:"quit"`
 }
 
 func ReadZonesFromFile(now time.Time, zoneConf ConfigFileZone) (*Zone, error) {
 	name := zoneConf.Name
 	dbName := zoneConf.ID
 
-	loc, err := time.LoadLocation(dbName)
+	loc, err := LoadLocation(dbName)
 	if err != nil {
 		return nil, fmt.Errorf("looking up zone %s: %w", dbName, err)
 	}
 	if name == "" {
 		name = loc.String()
 	}
+	bands, err := ReadBandsFromFile(zoneConf.Bands)
+	if err != nil {
+		return nil, fmt.Errorf("zone %s: %w", name, err)
+	}
+	if err := CheckHolidays(zoneConf.Holidays); err != nil {
+		return nil, fmt.Errorf("zone %s: %w", name, err)
+	}
+	var coordinates *Coordinates
+	switch {
+	case zoneConf.Latitude != nil && zoneConf.Longitude != nil:
+		coordinates = &Coordinates{Latitude: *zoneConf.Latitude, Longitude: *zoneConf.Longitude}
+		if err := CheckCoordinates(*coordinates); err != nil {
+			return nil, fmt.Errorf("zone %s: %w", name, err)
+		}
+	case zoneConf.Latitude != nil || zoneConf.Longitude != nil:
+		return nil, fmt.Errorf("zone %s: needs both a latitude and a longitude", name)
+	}
 	return &Zone{
-		Loc:    loc,
-		DbName: loc.String(),
-		Name:   name,
+		Loc:         loc,
+		DbName:      loc.String(),
+		Name:        name,
+		Bands:       bands,
+		Holidays:    zoneConf.Holidays,
+		Military:    zoneConf.Military,
+		Coordinates: coordinates,
 	}, nil
 }
 
+// ReadBandsFromFile validates bands from the config file.
+func ReadBandsFromFile(bandConfs []ConfigFileBand) ([]Band, error) {
+	var bands []Band
+	for _, bandConf := range bandConfs {
+		band := Band(bandConf)
+		if err := CheckBand(band); err != nil {
+			return nil, err
+		}
+		bands = append(bands, band)
+	}
+	return bands, nil
+}
+
+func ReadDeadlineFromFile(now time.Time, deadlineConf ConfigFileDeadline) (*Deadline, error) {
+	value := deadlineConf.Time
+	if deadlineConf.Zone != "" {
+		value = fmt.Sprintf("%s %s", value, deadlineConf.Zone)
+	}
+	return ParseDeadline(deadlineConf.Name, value, now)
+}
+
 func DefaultConfigFile() (*string, error) {
 	// Return early if we can't find a home dir.
 	homeDir, err := os.UserHomeDir()
@@ -90,6 +226,13 @@ func LoadConfigFile(configFilePath string, now time.Time) (*Config, error) {
 		return nil, fmt.Errorf("Parsing %s: %w\n", configFilePath, err)
 	}
 
+	// Zones from the command line's zoneinfo take precedence
+	if config.ZoneInfo != "" && zoneInfo == nil {
+		if err := SetZoneInfo(config.ZoneInfo); err != nil {
+			return nil, err
+		}
+	}
+
 	// Add zones from config file
 	zones := make([]*Zone, len(config.Zones))
 	for i, zoneConf := range config.Zones {
@@ -100,8 +243,139 @@ func LoadConfigFile(configFilePath string, now time.Time) (*Config, error) {
 		zones[i] = zone
 	}
 
+	// Add deadlines from config file
+	deadlines := make([]*Deadline, len(config.Deadlines))
+	for i, deadlineConf := range config.Deadlines {
+		deadline, err := ReadDeadlineFromFile(now, deadlineConf)
+		if err != nil {
+			return nil, err
+		}
+		deadlines[i] = deadline
+	}
+
+	// Add alarms from config file
+	alarms := make([]*Alarm, len(config.Alarms))
+	for i, spec := range config.Alarms {
+		alarm, err := ParseAlarm(spec, now)
+		if err != nil {
+			return nil, err
+		}
+		alarms[i] = alarm
+	}
+
+	if err := checkMinuteStep(config.MinuteStep); err != nil {
+		return nil, err
+	}
+
+	// Add profiles from config file
+	profiles := make(map[string]*Profile, len(config.Profiles))
+	for name, profileConf := range config.Profiles {
+		if err := checkMinuteStep(profileConf.MinuteStep); err != nil {
+			return nil, fmt.Errorf("Profile %s: %w", name, err)
+		}
+		profile := Profile{MinuteStep: profileConf.MinuteStep}
+		for _, zoneConf := range profileConf.Zones {
+			zone, err := ReadZonesFromFile(now, zoneConf)
+			if err != nil {
+				return nil, fmt.Errorf("Profile %s: %w", name, err)
+			}
+			profile.Zones = append(profile.Zones, zone)
+		}
+		profiles[name] = &profile
+	}
+
+	bands, err := ReadBandsFromFile(config.Bands)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckHolidays(config.Holidays); err != nil {
+		return nil, err
+	}
+
+	formats := make([]Format, len(config.Formats))
+	for i, formatConf := range config.Formats {
+		formats[i] = Format(formatConf)
+	}
+	if err := CheckFormats(formats); err != nil {
+		return nil, err
+	}
+
+	if config.Locale != "" {
+		conf.Locale, err = LoadLocale(config.Locale)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	theme, err := ReadThemeFromFile(config.Theme)
+	if err != nil {
+		return nil, fmt.Errorf("Theme: %w", err)
+	}
+
 	conf.Zones = zones
+	conf.Deadlines = deadlines
+	conf.Alarms = alarms
+	conf.AlarmCommand = config.AlarmCommand
+	conf.MinuteStep = config.MinuteStep
+	if config.Header != nil {
+		conf.Header = *config.Header
+		conf.HideHeader = *config.Header == ""
+	}
+	conf.DisableMouse = config.Mouse != nil && !*config.Mouse
+	conf.Accessible = config.Accessible
+	conf.Profiles = profiles
+	conf.Theme = theme
+	conf.Bands = bands
+	conf.Holidays = config.Holidays
+	conf.Formats = formats
+	conf.Military = config.Military
 	conf.Keymaps = Keymaps(config.Keymaps)
 
 	return &conf, nil
 }
+
+// ReadThemeFromFile starts from the named built-in theme, and overrides
+// its colors with those set in the config file.
+func ReadThemeFromFile(themeConf ConfigFileTheme) (*Theme, error) {
+	theme, err := LoadTheme(themeConf.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := overridePalette(&theme.Dark, themeConf.Dark); err != nil {
+		return nil, fmt.Errorf("dark: %w", err)
+	}
+	if err := overridePalette(&theme.Light, themeConf.Light); err != nil {
+		return nil, fmt.Errorf("light: %w", err)
+	}
+	return theme, nil
+}
+
+func overridePalette(palette *Palette, paletteConf ConfigFilePalette) error {
+	colors := []struct {
+		key   string
+		value string
+		color *string
+	}{
+		{"morning", paletteConf.Morning, &palette.Morning},
+		{"day", paletteConf.Day, &palette.Day},
+		{"evening", paletteConf.Evening, &palette.Evening},
+		{"night", paletteConf.Night, &palette.Night},
+		{"cursor_text", paletteConf.CursorText, &palette.CursorText},
+		{"highlight", paletteConf.Highlight, &palette.Highlight},
+		{"dates", paletteConf.Dates, &palette.Dates},
+		{"date_time", paletteConf.DateTime, &palette.DateTime},
+		{"text", paletteConf.Text, &palette.Text},
+		{"status", paletteConf.Status, &palette.Status},
+	}
+	for _, c := range colors {
+		if c.value == "" {
+			continue
+		}
+		if err := CheckColor(c.value); err != nil {
+			return fmt.Errorf("%s: %w", c.key, err)
+		}
+		*c.color = c.value
+	}
+	return nil
+}
@@ -40,6 +40,46 @@ func TestExampleConfigFile(t *testing.T) {
 		t.Errorf("Expected at least 4 zones in %s, found %v", tomlPath, len(config.Zones))
 	}
 
+	if len(config.Alarms) < 2 {
+		t.Errorf("Expected at least 2 alarms in %s, found %v", tomlPath, len(config.Alarms))
+	}
+
+	if len(config.Deadlines) < 1 {
+		t.Errorf("Expected at least 1 deadline in %s, found %v", tomlPath, len(config.Deadlines))
+	}
+
+	if len(config.Bands) < 4 {
+		t.Errorf("Expected at least 4 bands in %s, found %v", tomlPath, len(config.Bands))
+	}
+
+	if len(config.Zones[2].Bands) != 1 {
+		t.Errorf("Expected bands for %s in %s, found %v", config.Zones[2].Name, tomlPath, config.Zones[2].Bands)
+	}
+
+	if config.Header == "" || config.HideHeader {
+		t.Errorf("Expected a header in %s, found %q", tomlPath, config.Header)
+	}
+
+	if bangalore := config.Zones[2]; bangalore.Coordinates == nil || bangalore.Coordinates.Latitude != 12.97 {
+		t.Errorf("Expected the coordinates of %s in %s, found %v", bangalore.Name, tomlPath, bangalore.Coordinates)
+	}
+
+	if utc := config.Zones[3]; utc.Military == nil || !*utc.Military {
+		t.Errorf("Expected 24-hour time in %s for %s, found %v", tomlPath, utc.Name, utc.Military)
+	}
+
+	if len(config.Formats) < 4 {
+		t.Errorf("Expected at least 4 formats in %s, found %v", tomlPath, config.Formats)
+	}
+
+	if len(config.Holidays) < 2 || len(config.Zones[2].Holidays) < 2 {
+		t.Errorf("Expected holidays in %s, found %v and %v", tomlPath, config.Holidays, config.Zones[2].Holidays)
+	}
+
+	if config.Theme == nil || config.Theme.Dark.Day != "#FFD700" {
+		t.Errorf("Expected the dark day color from %s, found %v", tomlPath, config.Theme)
+	}
+
 	if len(config.Keymaps.OpenWeb) < 2 {
 		t.Errorf("Expected at least 2 keys for open_web in %s, found %v", tomlPath, len(config.Keymaps.OpenWeb))
 	}
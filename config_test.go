@@ -88,6 +88,67 @@ func TestLoadConfigParser(t *testing.T) {
 	os.Setenv("TZ_LIST", oldEnv)
 }
 
+func TestLoadConfigProfile(t *testing.T) {
+	oldTzList, tzListWasSet := os.LookupEnv("TZ_LIST")
+	os.Unsetenv("TZ_LIST")
+	defer func() {
+		os.Unsetenv("TZ_PROFILE")
+		if tzListWasSet {
+			os.Setenv("TZ_LIST", oldTzList)
+		}
+	}()
+
+	tests := []struct {
+		profile    string
+		minuteStep int
+		zones      string
+	}{
+		{"", 15, "Local;NZ;Sydney;Bangalore;UTC"},
+		{"travel", 30, "Local;New York;London"},
+		{"unknown", 0, ""},
+	}
+
+	tomlPath := "./example-conf.toml"
+	for _, test := range tests {
+		os.Setenv("TZ_PROFILE", test.profile)
+		config, err := LoadConfig(tomlPath, nil)
+		if err != nil {
+			if test.zones != "" {
+				t.Errorf("Profile %q: %v", test.profile, err)
+			}
+			continue
+		}
+		if test.zones == "" {
+			t.Errorf("Expected an error for profile %q", test.profile)
+			continue
+		}
+
+		if config.MinuteStep != test.minuteStep {
+			t.Errorf("Profile %q: expected a minute step of %d, but got %d", test.profile, test.minuteStep, config.MinuteStep)
+		}
+		names := make([]string, len(config.Zones))
+		for i, z := range config.Zones {
+			names[i] = z.Name
+		}
+		if observed := strings.Join(names, ";"); observed != test.zones {
+			t.Errorf("Profile %q: expected zones '%v', but got '%v'", test.profile, test.zones, observed)
+		}
+	}
+}
+
+func TestCheckMinuteStep(t *testing.T) {
+	for _, step := range []int{0, 1, 5, 10, 15, 30, 60} {
+		if err := checkMinuteStep(step); err != nil {
+			t.Errorf("Expected minute step %d to be valid: %v", step, err)
+		}
+	}
+	for _, step := range []int{-5, 7, 45, 90} {
+		if err := checkMinuteStep(step); err == nil {
+			t.Errorf("Expected minute step %d to be invalid", step)
+		}
+	}
+}
+
 func TestLoadDefaultConfig(t *testing.T) {
 	_, err := LoadDefaultConfig(nil)
 	if err != nil {
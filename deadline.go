@@ -0,0 +1,127 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AnywhereOnEarth is the UTC-12 zone of "AoE" deadlines: such a deadline
+// has not passed as long as it is still that day somewhere on Earth.
+var AnywhereOnEarth = time.FixedZone("AoE", -12*60*60)
+
+// Deadline is a named point in time, anchored in a time zone.
+type Deadline struct {
+	Name string
+	Time time.Time
+}
+
+// LoadDeadlineLocation returns the zone named in a deadline: "AoE", or a
+// name from the tz database. An empty name is the local zone.
+func LoadDeadlineLocation(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "":
+		return time.Local, nil
+	case "aoe":
+		return AnywhereOnEarth, nil
+	}
+	return LoadLocation(name)
+}
+
+// ParseDeadline reads a deadline such as "2024-06-01 23:59 AoE", or
+// "17:00 Europe/Paris" for the next 5PM in Paris after `now`. RFC 3339
+// timestamps are also accepted.
+func ParseDeadline(name string, value string, now time.Time) (*Deadline, error) {
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(value)); err == nil {
+		return &Deadline{Name: name, Time: t}, nil
+	}
+
+	fields := strings.Fields(value)
+	var date, clock, zone string
+	switch len(fields) {
+	case 1:
+		clock = fields[0]
+	case 2:
+		if strings.Contains(fields[0], "-") {
+			date, clock = fields[0], fields[1]
+		} else {
+			clock, zone = fields[0], fields[1]
+		}
+	case 3:
+		date, clock, zone = fields[0], fields[1], fields[2]
+	default:
+		return nil, fmt.Errorf("invalid deadline %q", value)
+	}
+
+	loc, err := LoadDeadlineLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("looking up deadline zone %s: %w", zone, err)
+	}
+
+	if date == "" {
+		hm, err := time.Parse("15:04", clock)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deadline %q: %w", value, err)
+		}
+		nowInZone := now.In(loc)
+		t := time.Date(nowInZone.Year(), nowInZone.Month(), nowInZone.Day(), hm.Hour(), hm.Minute(), 0, 0, loc)
+		if t.Before(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return &Deadline{Name: name, Time: t}, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02 15:04", date+" "+clock, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deadline %q: %w", value, err)
+	}
+	return &Deadline{Name: name, Time: t}, nil
+}
+
+// Countdown describes the time left before the deadline at time `t`.
+func (d Deadline) Countdown(t time.Time, seconds bool) string {
+	remaining := d.Time.Sub(t)
+	if remaining < 0 {
+		return fmt.Sprintf("passed %s ago", formatDuration(-remaining, seconds))
+	}
+	return fmt.Sprintf("%s left", formatDuration(remaining, seconds))
+}
+
+// Format a positive duration in days, hours and minutes, e.g. "2d 04h 13m".
+func formatDuration(d time.Duration, seconds bool) string {
+	if !seconds {
+		d = d.Truncate(time.Minute)
+	}
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	parts = append(parts, fmt.Sprintf("%02dh", hours), fmt.Sprintf("%02dm", minutes))
+	if seconds {
+		parts = append(parts, fmt.Sprintf("%02ds", d/time.Second))
+	}
+	return strings.Join(parts, " ")
+}
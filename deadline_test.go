@@ -0,0 +1,77 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDeadline(t *testing.T) {
+	now := time.Date(2024, time.May, 31, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"2024-06-01 23:59 AoE", "2024-06-02T11:59:00Z", true},
+		{"2024-06-01 23:59 aoe", "2024-06-02T11:59:00Z", true},
+		{"2024-06-01 09:00 Europe/Paris", "2024-06-01T07:00:00Z", true},
+		{"2024-06-01T09:00:00+02:00", "2024-06-01T07:00:00Z", true},
+		{"17:00 UTC", "2024-06-01T17:00:00Z", true},
+		{"21:00 Europe/Paris", "2024-05-31T19:00:00Z", true},
+		{"2024-06-01 23:59 Europe/Nowhere", "", false},
+		{"tomorrow", "", false},
+		{"", "", false},
+	}
+	for _, test := range tests {
+		deadline, err := ParseDeadline("test", test.value, now)
+		if test.ok != (err == nil) {
+			t.Errorf("Expected %v for %q, but got: %v", test.ok, test.value, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		observed := deadline.Time.UTC().Format(time.RFC3339)
+		if observed != test.expected {
+			t.Errorf("Expected %q to be %s, but got %s", test.value, test.expected, observed)
+		}
+	}
+}
+
+func TestDeadlineCountdown(t *testing.T) {
+	deadline := Deadline{Time: time.Date(2024, time.June, 2, 11, 59, 0, 0, time.UTC)}
+
+	tests := []struct {
+		time     time.Time
+		seconds  bool
+		expected string
+	}{
+		{time.Date(2024, time.May, 31, 7, 45, 30, 0, time.UTC), false, "2d 04h 13m left"},
+		{time.Date(2024, time.May, 31, 7, 45, 30, 0, time.UTC), true, "2d 04h 13m 30s left"},
+		{time.Date(2024, time.June, 2, 11, 0, 0, 0, time.UTC), false, "00h 59m left"},
+		{time.Date(2024, time.June, 2, 13, 0, 0, 0, time.UTC), false, "passed 01h 01m ago"},
+	}
+	for _, test := range tests {
+		observed := deadline.Countdown(test.time, test.seconds)
+		if observed != test.expected {
+			t.Errorf("Expected countdown “%s”, but got “%s”", test.expected, observed)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Number of transitions listed per zone by default.
+const DefaultTransitionCount = 3
+
+// Give up looking for offset changes after so many zone periods: some
+// zones change abbreviations without changing offsets.
+const maxZonePeriods = 10
+
+// Transition is a change of UTC offset in a zone.
+type Transition struct {
+	Zone      *Zone
+	Time      time.Time // First instant with the new offset
+	OldName   string
+	OldOffset int // Seconds east of UTC
+	NewName   string
+	NewOffset int
+}
+
+// NextTransition finds the first offset transition strictly after `t`.
+func NextTransition(z *Zone, t time.Time) (*Transition, bool) {
+	t = t.In(z.Loc)
+	oldName, oldOffset := t.Zone()
+	for i := 0; i < maxZonePeriods; i++ {
+		_, end := t.ZoneBounds()
+		if end.IsZero() {
+			return nil, false
+		}
+		newName, newOffset := end.Zone()
+		if newOffset != oldOffset {
+			return &Transition{z, end, oldName, oldOffset, newName, newOffset}, true
+		}
+		t = end
+	}
+	return nil, false
+}
+
+// PreviousTransition finds the last offset transition at, or before `t`.
+func PreviousTransition(z *Zone, t time.Time) (*Transition, bool) {
+	t = t.In(z.Loc)
+	newName, newOffset := t.Zone()
+	for i := 0; i < maxZonePeriods; i++ {
+		start, _ := t.ZoneBounds()
+		if start.IsZero() {
+			return nil, false
+		}
+		before := start.Add(-time.Nanosecond)
+		oldName, oldOffset := before.Zone()
+		if oldOffset != newOffset {
+			return &Transition{z, start, oldName, oldOffset, newName, newOffset}, true
+		}
+		t = before
+	}
+	return nil, false
+}
+
+// UpcomingTransitions lists the next `n` offset transitions after `t`.
+func UpcomingTransitions(z *Zone, t time.Time, n int) []*Transition {
+	var transitions []*Transition
+	for len(transitions) < n {
+		transition, ok := NextTransition(z, t)
+		if !ok {
+			break
+		}
+		transitions = append(transitions, transition)
+		t = transition.Time
+	}
+	return transitions
+}
+
+// Wall clock times just before, and at the transition.
+func (tr Transition) LocalTimes() (before, after time.Time) {
+	before = tr.Time.In(time.FixedZone(tr.OldName, tr.OldOffset))
+	after = tr.Time.In(tr.Zone.Loc)
+	return
+}
+
+// Shift is the change of the difference between two zones, caused by a
+// transition in one of them.
+type Shift struct {
+	Zone   *Zone
+	Before int // Seconds from the transitioning zone, before
+	After  int
+}
+
+// Shifts lists the zones whose difference with the transitioning zone
+// changes.
+func (tr Transition) Shifts(zones []*Zone) []Shift {
+	var shifts []Shift
+	for _, zone := range zones {
+		if zone.Loc.String() == tr.Zone.Loc.String() {
+			continue
+		}
+		_, otherBefore := tr.Time.Add(-time.Nanosecond).In(zone.Loc).Zone()
+		_, otherAfter := tr.Time.In(zone.Loc).Zone()
+		shift := Shift{zone, otherBefore - tr.OldOffset, otherAfter - tr.NewOffset}
+		if shift.Before != shift.After {
+			shifts = append(shifts, shift)
+		}
+	}
+	return shifts
+}
+
+// Format an offset in seconds as "+01:00".
+func formatOffset(seconds int) string {
+	return time.Unix(0, 0).In(time.FixedZone("", seconds)).Format("-07:00")
+}
+
+// Describe the transition on one line.
+func (tr Transition) String() string {
+	before, after := tr.LocalTimes()
+	return fmt.Sprintf(
+		"%s, %s → %s (%s → %s)",
+		after.Format("Mon Jan 02 2006"),
+		before.Format("15:04 MST"),
+		after.Format("15:04 MST"),
+		formatOffset(tr.OldOffset),
+		formatOffset(tr.NewOffset),
+	)
+}
+
+// PrintTransitions writes a report of the next `n` transitions of each
+// zone after `t`, with their effect on the other zones.
+func PrintTransitions(w io.Writer, zones []*Zone, t time.Time, n int) {
+	for i, zone := range zones {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%s)\n", zone.Name, zone.Loc)
+
+		transitions := UpcomingTransitions(zone, t, n)
+		if len(transitions) == 0 {
+			fmt.Fprintln(w, "  No upcoming offset transitions")
+		}
+		for _, transition := range transitions {
+			fmt.Fprintf(w, "  %s\n", transition)
+			for _, shift := range transition.Shifts(zones) {
+				fmt.Fprintf(
+					w,
+					"    %s: %s → %s\n",
+					shift.Zone.Name,
+					formatOffset(shift.Before),
+					formatOffset(shift.After),
+				)
+			}
+		}
+	}
+}
+
+// Render the TUI panel of the next transition in each zone, after the
+// clock time.
+func dstPanel(m *model) string {
+	s := strings.Builder{}
+	for _, zone := range m.zones {
+		transition, ok := NextTransition(zone, m.clock.t)
+		if !ok {
+			continue
+		}
+		var shifted []string
+		for _, shift := range transition.Shifts(m.zones) {
+			shifted = append(shifted, shift.Zone.Name)
+		}
+		line := fmt.Sprintf("%-20s %s", zone.Name, transition)
+		s.WriteString(fmt.Sprintf("  %s", normalTextStyle(line)))
+		if len(shifted) > 0 {
+			s.WriteString(dateTimeStyle(", shifts " + strings.Join(shifted, ", ")).String())
+		}
+		s.WriteString("\n")
+	}
+	if s.Len() == 0 {
+		return fmt.Sprintf("  %s\n", dateTimeStyle("No upcoming offset transitions"))
+	}
+	return s.String()
+}
+
+// The `tz dst` command: report upcoming transitions of configured zones.
+func dstCommand(args []string) int {
+	flags := flag.NewFlagSet("dst", flag.ExitOnError)
+	count := flags.Int("n", DefaultTransitionCount, "number of transitions per zone")
+	when := flags.Int64("when", 0, "time in seconds since unix epoch")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: tz dst [flags] [zones...]\n\nList upcoming UTC offset transitions.\n\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	config, err := LoadDefaultConfig(flags.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Config error: %s\n", err)
+		return 2
+	}
+
+	t := time.Now()
+	if *when != 0 {
+		t = time.Unix(*when, 0)
+	}
+	PrintTransitions(os.Stdout, config.Zones, t, *count)
+	return 0
+}
+
+// Move the clock to the next, or previous offset transition of the
+// highlighted zone, or of any zone when none is highlighted.
+func (m *model) jumpToTransition(forward bool) {
+	zones := m.zones
+	if m.highlighted > 0 {
+		zones = m.zones[m.highlighted-1 : m.highlighted]
+	}
+
+	var closest *Transition
+	for _, zone := range zones {
+		var transition *Transition
+		var ok bool
+		if forward {
+			transition, ok = NextTransition(zone, m.clock.t)
+		} else {
+			transition, ok = PreviousTransition(zone, m.clock.t.Add(-time.Nanosecond))
+		}
+		if !ok {
+			continue
+		}
+		if closest == nil ||
+			(forward && transition.Time.Before(closest.Time)) ||
+			(!forward && transition.Time.After(closest.Time)) {
+			closest = transition
+		}
+	}
+
+	if closest == nil {
+		m.message = "No offset transition found"
+		return
+	}
+	m.clock = *NewClockTime(closest.Time.In(m.clock.t.Location()))
+	m.message = fmt.Sprintf("%s: %s", closest.Zone.Name, closest)
+}
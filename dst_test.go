@@ -0,0 +1,153 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func loadTestZone(t *testing.T, name string) *Zone {
+	zone, err := ReadZoneFromString(time.Now(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return zone
+}
+
+func TestNextAndPreviousTransition(t *testing.T) {
+	paris := loadTestZone(t, "Europe/Paris")
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	next, ok := NextTransition(paris, start)
+	if !ok {
+		t.Fatal("Expected a transition in Europe/Paris")
+	}
+	expected := "2024-03-31T01:00:00Z"
+	if observed := next.Time.UTC().Format(time.RFC3339); observed != expected {
+		t.Errorf("Expected next transition at %s, but got %s", expected, observed)
+	}
+	if next.OldOffset != 3600 || next.NewOffset != 7200 {
+		t.Errorf("Expected offsets to go from 3600 to 7200, but got %v to %v", next.OldOffset, next.NewOffset)
+	}
+
+	previous, ok := PreviousTransition(paris, start)
+	if !ok {
+		t.Fatal("Expected a previous transition in Europe/Paris")
+	}
+	expected = "2023-10-29T01:00:00Z"
+	if observed := previous.Time.UTC().Format(time.RFC3339); observed != expected {
+		t.Errorf("Expected previous transition at %s, but got %s", expected, observed)
+	}
+
+	// A transition is not after itself, but it is its own previous one.
+	if again, _ := NextTransition(paris, next.Time); !again.Time.After(next.Time) {
+		t.Errorf("Expected transition after %v, but got %v", next.Time, again.Time)
+	}
+	if again, _ := PreviousTransition(paris, next.Time); !again.Time.Equal(next.Time) {
+		t.Errorf("Expected transition at %v, but got %v", next.Time, again.Time)
+	}
+
+	if _, ok := NextTransition(loadTestZone(t, "Asia/Kolkata"), start); ok {
+		t.Errorf("Expected no transition in Asia/Kolkata")
+	}
+}
+
+func TestPrintTransitions(t *testing.T) {
+	zones := []*Zone{
+		loadTestZone(t, "UTC"),
+		loadTestZone(t, "Europe/Paris"),
+		loadTestZone(t, "Europe/London"),
+		loadTestZone(t, "US/Central"),
+	}
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var builder strings.Builder
+	PrintTransitions(&builder, zones, start, 2)
+	observed := builder.String()
+
+	// Paris and London move together, so neither shifts the other.
+	expectations := []string{
+		"UTC (UTC)\n  No upcoming offset transitions\n",
+		"Europe/Paris (Europe/Paris)\n  Sun Mar 31 2024, 02:00 CET → 03:00 CEST (+01:00 → +02:00)\n    UTC: -01:00 → -02:00\n    US/Central: -06:00 → -07:00\n  Sun Oct 27 2024",
+		"US/Central (US/Central)\n  Sun Mar 10 2024, 02:00 CST → 03:00 CDT (-06:00 → -05:00)\n",
+	}
+	for _, expected := range expectations {
+		if !strings.Contains(observed, expected) {
+			t.Errorf("Expected “%s” in report, but got:\n%s", expected, observed)
+		}
+	}
+}
+
+func TestDSTPanel(t *testing.T) {
+	state := utcMinuteAfterMidnightModel
+	state.zones = LoadDstTestZones(t)[:3]
+	state.showDST = true
+
+	observed := stripAnsiControlSequences(state.View())
+	expected := "Europe/Paris         Sun Mar 25 2018, 02:00 CET → 03:00 CEST (+01:00 → +02:00), shifts UTC, Israel"
+	if !strings.Contains(observed, expected) {
+		t.Errorf("Expected “%s” in DST panel, but got:\n%s", expected, observed)
+	}
+}
+
+func TestUpdateJumpToTransition(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 12, 34, 0, 0, time.UTC)
+	m := model{
+		zones:   []*Zone{loadTestZone(t, "UTC"), loadTestZone(t, "Europe/Paris"), loadTestZone(t, "US/Central")},
+		keymaps: DefaultKeymaps,
+		clock:   *NewClockTime(start),
+	}
+	keys := func(keys string) {
+		for _, key := range keys {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		}
+	}
+
+	tests := []struct {
+		keys     string
+		expected string
+	}{
+		// Any zone: US/Central moves first, then Europe/Paris
+		{"]", "2024-03-10T08:00:00Z"},
+		{"]", "2024-03-31T01:00:00Z"},
+		{"[", "2024-03-10T08:00:00Z"},
+		// Highlighted US/Central only
+		{"jjj]", "2024-11-03T07:00:00Z"},
+		{"[[", "2023-11-05T07:00:00Z"},
+	}
+	for _, test := range tests {
+		keys(test.keys)
+		observed := m.clock.t.UTC().Format(time.RFC3339)
+		if observed != test.expected {
+			t.Errorf("Expected %q to move clock to %s, but got %s", test.keys, test.expected, observed)
+		}
+		if m.message == "" {
+			t.Errorf("Expected %q to announce the transition", test.keys)
+		}
+	}
+
+	m.zones = m.zones[:1]
+	m.highlighted = 0
+	keys("]")
+	if m.message != "No offset transition found" {
+		t.Errorf("Expected no transition in UTC, but got %q", m.message)
+	}
+}
@@ -0,0 +1,174 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is a named rendering of the date and time of zones: a Go layout,
+// like "Mon 15:04", or a strftime pattern, like "%a %H:%M", when marked so.
+// Without a layout, it is one of the built-in formats.
+type Format struct {
+	Name     string
+	Layout   string
+	Strftime bool // The layout is a strftime pattern, not a Go layout
+}
+
+// DefaultFormats are the format styles cycled through, unless configured.
+// Their order matches the FormatStyle constants.
+var DefaultFormats = []Format{
+	{Name: "default"},
+	{Name: "iso"},
+	{Name: "unix"},
+}
+
+// Format `t` with the layout of the format.
+func (f Format) Format(t time.Time) string {
+	if f.Strftime {
+		s, _ := strftime(t, f.Layout)
+		return s
+	}
+	return t.Format(f.Layout)
+}
+
+// Go layouts of the strftime conversions.
+var strftimeLayouts = map[byte]string{
+	'a': "Mon",
+	'A': "Monday",
+	'b': "Jan",
+	'B': "January",
+	'd': "02",
+	'e': "_2",
+	'F': "2006-01-02",
+	'h': "Jan",
+	'H': "15",
+	'I': "03",
+	'm': "01",
+	'M': "04",
+	'p': "PM",
+	'R': "15:04",
+	'S': "05",
+	'T': "15:04:05",
+	'y': "06",
+	'Y': "2006",
+	'z': "-0700",
+	'Z': "MST",
+}
+
+// Format `t` with a strftime pattern. Conversions are those of
+// strftimeLayouts, and %j, %s, %u, %V, %n, %t and %%.
+func strftime(t time.Time, pattern string) (string, error) {
+	s := strings.Builder{}
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			s.WriteByte(pattern[i])
+			continue
+		}
+		i++
+		if i == len(pattern) {
+			return "", fmt.Errorf("pattern %q ends with %%", pattern)
+		}
+		if layout, ok := strftimeLayouts[pattern[i]]; ok {
+			s.WriteString(t.Format(layout))
+			continue
+		}
+		switch pattern[i] {
+		case 'j':
+			s.WriteString(fmt.Sprintf("%03d", t.YearDay()))
+		case 's':
+			s.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case 'u':
+			s.WriteString(strconv.Itoa((int(t.Weekday())+6)%7 + 1))
+		case 'V':
+			_, week := t.ISOWeek()
+			s.WriteString(fmt.Sprintf("%02d", week))
+		case 'n':
+			s.WriteByte('\n')
+		case 't':
+			s.WriteByte('\t')
+		case '%':
+			s.WriteByte('%')
+		default:
+			return "", fmt.Errorf("unknown conversion %%%c in %q", pattern[i], pattern)
+		}
+	}
+	return s.String(), nil
+}
+
+func isBuiltinFormat(name string) bool {
+	for _, format := range DefaultFormats {
+		if format.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckFormats validates named formats: their names are unique, and
+// they have a layout, unless they name a built-in format.
+func CheckFormats(formats []Format) error {
+	names := make(map[string]bool)
+	for _, format := range formats {
+		switch {
+		case format.Name == "":
+			return fmt.Errorf("formats need a name")
+		case names[format.Name]:
+			return fmt.Errorf("format %s is defined twice", format.Name)
+		case format.Layout == "" && !isBuiltinFormat(format.Name):
+			return fmt.Errorf("format %s needs a layout", format.Name)
+		}
+		names[format.Name] = true
+		if format.Strftime {
+			if _, err := strftime(time.Now(), format.Layout); err != nil {
+				return fmt.Errorf("format %s: %w", format.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// FindFormat returns the style of the format named `name`.
+func FindFormat(formats []Format, name string) (FormatStyle, error) {
+	for i, format := range formats {
+		if format.Name == name {
+			return FormatStyle(i), nil
+		}
+	}
+	var names []string
+	for _, format := range formats {
+		names = append(names, format.Name)
+	}
+	return 0, fmt.Errorf("Unknown format %s, use one of: %s", name, strings.Join(names, ", "))
+}
+
+// Formats cycled through by the format keys.
+func (m *model) formatList() []Format {
+	if len(m.formats) > 0 {
+		return m.formats
+	}
+	return DefaultFormats
+}
+
+// Format of the current format style.
+func (m *model) currentFormat() Format {
+	formats := m.formatList()
+	return formats[int(m.formatStyle)%len(formats)]
+}
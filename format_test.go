@@ -0,0 +1,121 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStrftime(t *testing.T) {
+	at := time.Date(2024, 3, 5, 14, 7, 9, 0, time.FixedZone("CET", 60*60))
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{"%Y-%m-%d %H:%M:%S", "2024-03-05 14:07:09"},
+		{"%a %e %b, %I:%M%p %Z", "Tue  5 Mar, 02:07PM CET"},
+		{"%A %B %y", "Tuesday March 24"},
+		{"%F %T %z", "2024-03-05 14:07:09 +0100"},
+		{"day %j, week %V, weekday %u", "day 065, week 10, weekday 2"},
+		{"%s", "1709644029"},
+		{"Monday 100%%", "Monday 100%"},
+	}
+	for _, test := range tests {
+		observed, err := strftime(at, test.pattern)
+		if err != nil {
+			t.Errorf("Pattern %q: %v", test.pattern, err)
+		}
+		if observed != test.expected {
+			t.Errorf("Expected %q to format as %q, but got %q", test.pattern, test.expected, observed)
+		}
+	}
+
+	for _, pattern := range []string{"%Q", "%H:%"} {
+		if _, err := strftime(at, pattern); err == nil {
+			t.Errorf("Expected pattern %q to be invalid", pattern)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	at := time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)
+	if observed := (Format{Name: "go", Layout: "Mon 15:04"}).Format(at); observed != "Tue 14:07" {
+		t.Errorf("Expected a Go layout, but got %q", observed)
+	}
+	if observed := (Format{Name: "c", Layout: "%a %H:%M", Strftime: true}).Format(at); observed != "Tue 14:07" {
+		t.Errorf("Expected a strftime pattern, but got %q", observed)
+	}
+	if observed := (Format{Name: "percent", Layout: "15:04 %"}).Format(at); observed != "14:07 %" {
+		t.Errorf("Expected a Go layout with a percent sign, but got %q", observed)
+	}
+}
+
+func TestCheckFormats(t *testing.T) {
+	valid := []Format{{Name: "iso"}, {Name: "short", Layout: "%H:%M", Strftime: true}, {Name: "go", Layout: "15:04"}, {Name: "percent", Layout: "15:04 %"}}
+	if err := CheckFormats(valid); err != nil {
+		t.Errorf("Expected valid formats: %v", err)
+	}
+	invalid := [][]Format{
+		{{Name: "", Layout: "15:04"}},
+		{{Name: "short"}},
+		{{Name: "short", Layout: "%H:%Q", Strftime: true}},
+		{{Name: "a", Layout: "15:04"}, {Name: "a", Layout: "15"}},
+	}
+	for _, formats := range invalid {
+		if err := CheckFormats(formats); err == nil {
+			t.Errorf("Expected formats %v to be invalid", formats)
+		}
+	}
+}
+
+func TestFindFormat(t *testing.T) {
+	if style, err := FindFormat(DefaultFormats, "unix"); err != nil || style != UnixFormatStyle {
+		t.Errorf("Expected the unix style, but got %v, %v", style, err)
+	}
+	if _, err := FindFormat(DefaultFormats, "unknown"); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
+
+func TestCustomFormatStyles(t *testing.T) {
+	m := utcMinuteAfterMidnightModel
+	m.formats = []Format{
+		{Name: "short", Layout: "%H:%M %a", Strftime: true},
+		{Name: "iso"},
+		{Name: "day", Layout: "Mon 2 Jan"},
+	}
+	zone := m.zones[0]
+	timeInZone := zone.currentTime(m.clock.t)
+	expected := []string{"00:01 Sun", "2017-11-05T00:01+00:00", "Sun 5 Nov", "00:01 Sun"}
+	next := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}}
+	for _, e := range expected {
+		if observed := m.formatZoneTime(zone, timeInZone); observed != e {
+			t.Errorf("Expected format style %v to show %q, but got %q", m.formatStyle, e, observed)
+		}
+		m.Update(next)
+	}
+
+	previous := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}}
+	m.Update(previous)
+	m.Update(previous)
+	if m.formatStyle != 2 {
+		t.Errorf("Expected the previous style to wrap around to 2, but got %v", m.formatStyle)
+	}
+}
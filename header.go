@@ -0,0 +1,71 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultHeader is shown above the zones, unless configured.
+const DefaultHeader = "What time is it?"
+
+// Expand the placeholders of the header template: {time} and {date} of
+// the clock, the active {profile}, and the {offset} of the clock from
+// `now`.
+func (m *model) headerText(now time.Time) string {
+	template := m.header
+	if template == "" {
+		template = tr(DefaultHeader)
+	}
+	clockFormat := "3:04PM"
+	if m.isMilitary {
+		clockFormat = "15:04"
+	}
+	return strings.NewReplacer(
+		"{time}", m.clock.t.Format(clockFormat),
+		"{date}", formatLocal(m.clock.t, "Mon Jan 02 2006"),
+		"{profile}", m.profile,
+		"{offset}", clockOffset(m.clock, now),
+	).Replace(template)
+}
+
+// Describe how far the clock is from `now`, like "+1d 02h 00m", or "now".
+func clockOffset(c Clock, now time.Time) string {
+	offset := c.t.Sub(now)
+	switch {
+	case c.isRealTime || offset.Abs() < time.Minute:
+		return "now"
+	case offset < 0:
+		return "-" + formatDuration(-offset, false)
+	}
+	return "+" + formatDuration(offset, false)
+}
+
+// Render the header, with blank lines around it, or nothing when hidden.
+func header(m *model) string {
+	if m.hideHeader {
+		return ""
+	}
+	return normalTextStyle("\n  " + m.headerText(time.Now()) + "\n\n").String()
+}
+
+// Lines above the zones, for the mouse to find them, counted in the
+// rendered header since templates can span several lines.
+func (m *model) headerLines() int {
+	return strings.Count(header(m), "\n")
+}
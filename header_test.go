@@ -0,0 +1,93 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeaderText(t *testing.T) {
+	now := time.Date(2024, 10, 30, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header   string
+		clock    Clock
+		military bool
+		expected string
+	}{
+		{"", *NewClockTime(now), false, DefaultHeader},
+		{"At {time} on {date}", *NewClockTime(now), true, "At 10:00 on Wed Oct 30 2024"},
+		{"At {time}", *NewClockTime(now), false, "At 10:00AM"},
+		{"{profile}: {offset}", *NewClockTime(now.Add(26 * time.Hour)), false, "work: +1d 02h 00m"},
+		{"{offset}", *NewClockTime(now.Add(-90 * time.Minute)), false, "-01h 30m"},
+		{"{offset}", *NewClockTime(now.Add(30 * time.Second)), false, "now"},
+	}
+	for _, test := range tests {
+		m := model{header: test.header, clock: test.clock, isMilitary: test.military, profile: "work"}
+		if observed := m.headerText(now); observed != test.expected {
+			t.Errorf("Expected header %q to be %q, but got %q", test.header, test.expected, observed)
+		}
+	}
+
+	m := model{clock: *NewClockNow()}
+	if observed := m.headerText(time.Now().Add(time.Hour)); !strings.Contains(observed, DefaultHeader) {
+		t.Errorf("Expected the default header, but got %q", observed)
+	}
+	if offset := clockOffset(*NewClockNow(), time.Now().Add(time.Hour)); offset != "now" {
+		t.Errorf("Expected a real time clock to be now, but got %q", offset)
+	}
+}
+
+func TestHiddenHeader(t *testing.T) {
+	tomlPath := filepath.Join(t.TempDir(), "conf.toml")
+	if err := os.WriteFile(tomlPath, []byte("header = \"\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadConfigFile(tomlPath, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !config.HideHeader {
+		t.Errorf("Expected an empty header to hide it")
+	}
+
+	m := utcMinuteAfterMidnightModel
+	m.hideHeader = config.HideHeader
+	if view := stripAnsiControlSequences(m.View()); !strings.HasPrefix(view, "  🕛 (UTC) UTC") {
+		t.Errorf("Expected the zones at the top of the view, but got %q", view)
+	}
+	if m.headerLines() != 0 {
+		t.Errorf("Expected no header lines, but got %v", m.headerLines())
+	}
+}
+
+func TestMultilineHeader(t *testing.T) {
+	m := utcMinuteAfterMidnightModel
+	if m.headerLines() != 3 {
+		t.Errorf("Expected 3 lines for the default header, but got %v", m.headerLines())
+	}
+	m.header = "{date}\n  {time}"
+	if m.headerLines() != 4 {
+		t.Errorf("Expected 4 lines for a header on 2 lines, but got %v", m.headerLines())
+	}
+	if target := m.hitTest(4, m.headerLines()); target.zone != 0 {
+		t.Errorf("Expected a click under the header to hit the first zone, but got %+v", target)
+	}
+}
@@ -0,0 +1,58 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+// Number of clock positions kept for undo.
+const MaxHistory = 100
+
+// History of clock positions, for undo and redo.
+type History struct {
+	past   []Clock
+	future []Clock
+}
+
+// Push records the position the clock is moving away from, and forgets
+// the positions that could be redone.
+func (h *History) Push(c Clock) {
+	h.past = append(h.past, c)
+	if len(h.past) > MaxHistory {
+		h.past = h.past[len(h.past)-MaxHistory:]
+	}
+	h.future = nil
+}
+
+// Undo returns the previous position of a clock currently at `c`.
+func (h *History) Undo(c Clock) (Clock, bool) {
+	if len(h.past) == 0 {
+		return c, false
+	}
+	previous := h.past[len(h.past)-1]
+	h.past = h.past[:len(h.past)-1]
+	h.future = append(h.future, c)
+	return previous, true
+}
+
+// Redo returns the position of the clock before the last undo.
+func (h *History) Redo(c Clock) (Clock, bool) {
+	if len(h.future) == 0 {
+		return c, false
+	}
+	next := h.future[len(h.future)-1]
+	h.future = h.future[:len(h.future)-1]
+	h.past = append(h.past, c)
+	return next, true
+}
@@ -0,0 +1,94 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHistory(t *testing.T) {
+	h := History{}
+	start := *NewClockTime(utcMinuteAfterMidnightTime)
+
+	if _, ok := h.Undo(start); ok {
+		t.Error("Expected nothing to undo")
+	}
+
+	later := start
+	later.AddHours(1)
+	h.Push(start)
+
+	clock, ok := h.Undo(later)
+	if !ok || !clock.t.Equal(start.t) {
+		t.Errorf("Expected undo to %v, but got %v", start.t, clock.t)
+	}
+	clock, ok = h.Redo(clock)
+	if !ok || !clock.t.Equal(later.t) {
+		t.Errorf("Expected redo to %v, but got %v", later.t, clock.t)
+	}
+	if _, ok := h.Redo(clock); ok {
+		t.Error("Expected nothing to redo")
+	}
+
+	for i := 0; i < 2*MaxHistory; i++ {
+		h.Push(start)
+	}
+	if len(h.past) != MaxHistory {
+		t.Errorf("Expected history to be capped to %d, but got %d", MaxHistory, len(h.past))
+	}
+}
+
+func TestUpdateUndoRedo(t *testing.T) {
+	m := model{
+		zones:   DefaultZones,
+		keymaps: DefaultKeymaps,
+		clock:   *NewClockTime(utcMinuteAfterMidnightTime),
+	}
+	keys := func(keys string) {
+		for _, key := range keys {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		}
+	}
+
+	tests := []struct {
+		keys     string
+		expected time.Duration
+	}{
+		{"llL", 26 * time.Hour},
+		{"u", 2 * time.Hour},
+		{"uu", 0},
+		{"u", 0},
+		{"U", time.Hour},
+		// Highlighting doesn't move the clock, nor clear redo
+		{"jk", time.Hour},
+		{"U", 2 * time.Hour},
+		// Moving clears redo
+		{"uh", 0},
+		{"U", 0},
+		{"u", time.Hour},
+	}
+	for _, test := range tests {
+		keys(test.keys)
+		observed := m.clock.t.Sub(utcMinuteAfterMidnightTime)
+		if observed != test.expected {
+			t.Errorf("Expected %q to move clock by %v, but got %v", test.keys, test.expected, observed)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
+)
+
+// Layout of the zones on screen.
+type Layout int
+
+const (
+	GridLayout Layout = iota
+	VerticalLayout
+	WeekLayout
+	MapLayout
+	CompactLayout
+)
+
+func (l Layout) next() Layout {
+	switch l {
+	case GridLayout:
+		return VerticalLayout
+	case VerticalLayout:
+		return WeekLayout
+	case WeekLayout:
+		return MapLayout
+	case MapLayout:
+		return CompactLayout
+	default:
+		return GridLayout
+	}
+}
+
+// Below this many visible hours, the grid is unreadable: use the compact
+// layout instead.
+const MinimumGridHours = 12
+
+// Layout to render in `width` columns: the chosen one, unless the grid,
+// the week overview or the map don't fit.
+func (m model) currentLayout(width int) Layout {
+	switch {
+	case m.layout == GridLayout && (width-2)/ColumnWidth < MinimumGridHours:
+		return CompactLayout
+	case m.layout == WeekLayout && width < weekRowWidth(1):
+		return CompactLayout
+	case m.layout == MapLayout && width < MinimumMapColumns:
+		return CompactLayout
+	}
+	return m.layout
+}
+
+// Render the zones one per line, with their date and time right-aligned.
+func compactZones(m *model, width int) string {
+	s := strings.Builder{}
+	for i, zone := range m.zones {
+		timeInZone := zone.currentTime(m.clock.t)
+		datetime := m.formatZoneTime(zone, timeInZone)
+		zoneString := m.formatZoneName(zone, timeInZone, fractionalOffsetNote(timeInZone, false))
+		clockString := zone.ClockEmoji(m.clock.t)
+
+		usedWidth := termenv.String(clockString + zoneString + datetime).Width()
+		rightAlignmentSpace := strings.Repeat(" ", max(0, width-usedWidth-MinimumZoneHeaderPadding))
+		s.WriteString(fmt.Sprintf(
+			"%s%s %s %s%s\n",
+			m.rowMarker(i),
+			clockString,
+			normalTextStyle(zoneString),
+			rightAlignmentSpace,
+			dateTimeStyle(datetime),
+		))
+	}
+	return s.String()
+}
+
+// Width of the column of a zone in the vertical layout
+const VerticalColumnWidth = 18
+
+// Lines around the timelines and zone headers in the vertical layout:
+// title and status bar.
+const VerticalLayoutMargin = 7
+
+// Lines of the zone headers in the vertical layout: names and dates, and
+// sunrise and sunset when shown.
+func (m *model) verticalHeaderLines() int {
+	if m.showSun {
+		return 3
+	}
+	return 2
+}
+
+// Timelines of the vertical layout, with as many rows as fit the
+// terminal.
+func (m *model) verticalTimelines() timelines {
+	rows := 24 * int(time.Hour/m.grid.Duration())
+	if m.termHeight > 0 {
+		rows = max(MinimumGridHours, m.termHeight-VerticalLayoutMargin-m.verticalHeaderLines())
+	}
+	return m.timelines(rows)
+}
+
+// Render the zones as columns, with their timelines running down the
+// screen.
+func verticalZones(m *model) string {
+	tl := m.verticalTimelines()
+
+	names := strings.Builder{}
+	dates := strings.Builder{}
+	suns := strings.Builder{}
+	columns := make([][]timelineCell, len(m.zones))
+	for i, zone := range m.zones {
+		timeInZone := zone.currentTime(m.clock.t)
+		names.WriteString(m.rowMarker(i))
+		names.WriteString(normalTextStyle(fitWidth(zone.Name, VerticalColumnWidth-2)).String())
+		dates.WriteString("  ")
+		dates.WriteString(dateTimeStyle(fitWidth(formatLocal(timeInZone, "Mon 02 MST"), VerticalColumnWidth-2)).String())
+		suns.WriteString("  ")
+		suns.WriteString(dateTimeStyle(fitWidth(m.sunNote(zone), VerticalColumnWidth-2)).String())
+		columns[i] = tl.cells(zone)
+	}
+
+	s := strings.Builder{}
+	s.WriteString(names.String() + "\n")
+	s.WriteString(dates.String() + "\n")
+	if m.showSun {
+		s.WriteString(suns.String() + "\n")
+	}
+	for row := 0; row < tl.last-tl.first; row++ {
+		for i, cells := range columns {
+			cell := cells[row]
+			label := cell.time.Format("3:04PM")
+			if m.zoneMilitary(m.zones[i]) {
+				label = cell.time.Format("15:04")
+			}
+
+			// Mark the first hour of a day with its date, or DST changes
+			var note string
+			switch {
+			case m.showDates && cell.dayChange:
+				note = formatLocal(cell.time, " Mon 02")
+			case m.showDates && cell.dstChange != "":
+				note = " " + cell.dstChange
+			}
+			marker := m.cellSymbol(m.zones[i], cell.time, tl.step)
+			if tl.deadlines[cell.column] {
+				marker = DeadlineMarker
+			}
+
+			s.WriteString("  ")
+			s.WriteString(timelineCellStyle(fmt.Sprintf("%7s", label), m.cellColor(m.zones[i], cell.time, tl.step), cell.column == tl.cursor))
+			s.WriteString(marker)
+			s.WriteString(dateTimeStyle(fitWidth(note, VerticalColumnWidth-2-7-termenv.String(marker).Width())).String())
+		}
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// Pad, or truncate `s` to exactly `width` cells.
+func fitWidth(s string, width int) string {
+	used := 0
+	for i, r := range s {
+		w := runewidth.RuneWidth(r)
+		if used+w > width {
+			return s[:i] + strings.Repeat(" ", width-used)
+		}
+		used += w
+	}
+	return s + strings.Repeat(" ", width-used)
+}
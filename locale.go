@@ -0,0 +1,183 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Translations embedded in the binary, one file per language.
+//
+//go:embed locales/*.toml
+var localeFiles embed.FS
+
+// Locale holds the names of days and months, and the UI strings of a
+// language.
+type Locale struct {
+	Name        string            `toml:"-"`
+	Days        []string          `toml:"days"` // From Sunday
+	ShortDays   []string          `toml:"short_days"`
+	Months      []string          `toml:"months"` // From January
+	ShortMonths []string          `toml:"short_months"`
+	Strings     map[string]string `toml:"strings"` // Translations of UI strings, by their English text
+	Military    bool              `toml:"-"`       // 24-hour time is usual in the locale
+}
+
+// English needs no translations.
+var English = Locale{Name: "en"}
+
+// The locale in use, or nil for English.
+var locale *Locale
+
+// Locales lists the names of the embedded translations.
+func Locales() []string {
+	entries, _ := localeFiles.ReadDir("locales")
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".toml"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LocaleFromEnv returns the locale of dates set in the environment, like
+// "fr_FR.UTF-8".
+func LocaleFromEnv() string {
+	for _, name := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Regions where English speakers use 12-hour time.
+var twelveHourRegions = []string{"US", "CA", "AU", "NZ", "IN", "PH", "PK", "BD", "EG", "SA", "MY"}
+
+// Whether 24-hour time is usual in a locale: it is outside of English,
+// and in English outside of twelveHourRegions. Without a locale, tz used
+// 12-hour time, and still does.
+func prefersMilitary(language string, region string) bool {
+	switch language {
+	case "", "C", "POSIX":
+		return false
+	case "en":
+		return region != "" && !slices.Contains(twelveHourRegions, region)
+	}
+	return true
+}
+
+// Split a locale like "fr_FR.UTF-8" into its name without the encoding
+// and modifier, "fr_FR", its language and its region.
+func splitLocale(locale string) (name string, language string, region string) {
+	name, _, _ = strings.Cut(locale, ".")
+	name, _, _ = strings.Cut(name, "@")
+	language, region, _ = strings.Cut(name, "_")
+	return name, language, region
+}
+
+// EnglishFor is English, with the usual clock of a locale that may have no
+// translations, like "nl_NL.UTF-8".
+func EnglishFor(name string) *Locale {
+	_, language, region := splitLocale(name)
+	l := English
+	l.Military = prefersMilitary(language, region)
+	return &l
+}
+
+// LoadLocale finds the translations of a locale, like "fr", "fr_FR" or
+// "fr_FR.UTF-8", falling back from the region to the language. The C
+// and POSIX locales are English.
+func LoadLocale(name string) (*Locale, error) {
+	name, language, region := splitLocale(name)
+	switch language {
+	case "", "C", "POSIX", "en":
+		return EnglishFor(name), nil
+	}
+
+	for _, candidate := range []string{name, language} {
+		data, err := localeFiles.ReadFile("locales/" + candidate + ".toml")
+		if err != nil {
+			continue
+		}
+		var l Locale
+		if err := toml.Unmarshal(data, &l); err != nil {
+			return nil, fmt.Errorf("Parsing locale %s: %w", candidate, err)
+		}
+		if len(l.Days) != 7 || len(l.ShortDays) != 7 || len(l.Months) != 12 || len(l.ShortMonths) != 12 {
+			return nil, fmt.Errorf("Locale %s needs 7 days and 12 months", candidate)
+		}
+		l.Name = candidate
+		l.Military = prefersMilitary(language, region)
+		return &l, nil
+	}
+	return nil, fmt.Errorf("Unknown locale %s, use one of: en, %s", name, strings.Join(Locales(), ", "))
+}
+
+// Translate a UI string into the language of the locale.
+func tr(s string) string {
+	if locale != nil {
+		if translation, ok := locale.Strings[s]; ok {
+			return translation
+		}
+	}
+	return s
+}
+
+// Names of days and months in Go layouts, longest first so that "Monday"
+// is not read as "Mon" followed by "day".
+var layoutNames = []string{"January", "Monday", "Jan", "Mon"}
+
+// Format `t` like time.Format, with the names of days and months of the
+// locale.
+func formatLocal(t time.Time, layout string) string {
+	if locale == nil || locale.Days == nil {
+		return t.Format(layout)
+	}
+
+	s := strings.Builder{}
+	for layout != "" {
+		// Find the next name in the layout
+		next, found := len(layout), ""
+		for _, name := range layoutNames {
+			if i := strings.Index(layout, name); i >= 0 && i < next {
+				next, found = i, name
+			}
+		}
+		s.WriteString(t.Format(layout[:next]))
+		switch found {
+		case "January":
+			s.WriteString(locale.Months[t.Month()-1])
+		case "Jan":
+			s.WriteString(locale.ShortMonths[t.Month()-1])
+		case "Monday":
+			s.WriteString(locale.Days[t.Weekday()])
+		case "Mon":
+			s.WriteString(locale.ShortDays[t.Weekday()])
+		}
+		layout = layout[next+len(found):]
+	}
+	return s.String()
+}
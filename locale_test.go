@@ -0,0 +1,192 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"", "en"},
+		{"C", "en"},
+		{"POSIX", "en"},
+		{"en_US.UTF-8", "en"},
+		{"fr", "fr"},
+		{"fr_CA.UTF-8", "fr"},
+		{"de_DE@euro", "de"},
+		{"ja_JP.UTF-8", "ja"},
+		{"xx_XX", ""},
+	}
+	for _, test := range tests {
+		l, err := LoadLocale(test.name)
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("Expected an error for locale %q", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Locale %q: %v", test.name, err)
+			continue
+		}
+		if l.Name != test.expected {
+			t.Errorf("Expected locale %q to be %s, but got %s", test.name, test.expected, l.Name)
+		}
+	}
+}
+
+// Every embedded locale translates the same strings.
+func TestEmbeddedLocales(t *testing.T) {
+	reference, err := LoadLocale("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range Locales() {
+		l, err := LoadLocale(name)
+		if err != nil {
+			t.Errorf("Locale %s: %v", name, err)
+			continue
+		}
+		for key := range reference.Strings {
+			if l.Strings[key] == "" {
+				t.Errorf("Locale %s does not translate %q", name, key)
+			}
+		}
+		if len(l.Strings) != len(reference.Strings) {
+			t.Errorf("Locale %s translates %d strings, but fr %d", name, len(l.Strings), len(reference.Strings))
+		}
+	}
+}
+
+func TestFormatLocal(t *testing.T) {
+	oldLocale := locale
+	defer func() { locale = oldLocale }()
+
+	at := time.Date(2024, 2, 5, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		locale   string
+		layout   string
+		expected string
+	}{
+		{"en", "Mon Jan 02, 15:04", "Mon Feb 05, 09:30"},
+		{"fr", "Mon Jan 02, 15:04", "lun. févr. 05, 09:30"},
+		{"fr", "Monday 2 January 2006", "lundi 5 février 2024"},
+		{"de", "Mon, 02. Jan", "Mo, 05. Feb"},
+		{"ja", "Jan 2 (Mon)", "2月 5 (月)"},
+		{"es", "Monday", "lunes"},
+	}
+	for _, test := range tests {
+		locale, _ = LoadLocale(test.locale)
+		if observed := formatLocal(at, test.layout); observed != test.expected {
+			t.Errorf("Locale %s: expected %q to format as %q, but got %q", test.locale, test.layout, test.expected, observed)
+		}
+	}
+
+	locale, _ = LoadLocale("fr")
+	if observed := tr("help"); observed != "aide" {
+		t.Errorf("Expected a translation of help, but got %q", observed)
+	}
+	if observed := tr("untranslated"); observed != "untranslated" {
+		t.Errorf("Expected untranslated strings to stay in English, but got %q", observed)
+	}
+}
+
+func TestLocaleConfig(t *testing.T) {
+	tomlPath := filepath.Join(t.TempDir(), "conf.toml")
+	os.WriteFile(tomlPath, []byte("locale = \"ja\"\n"), 0644)
+	config, err := LoadConfigFile(tomlPath, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Locale == nil || config.Locale.Name != "ja" {
+		t.Errorf("Expected the ja locale, but got %v", config.Locale)
+	}
+
+	os.WriteFile(tomlPath, []byte("locale = \"tlh\"\n"), 0644)
+	if _, err := LoadConfigFile(tomlPath, time.Now()); err == nil {
+		t.Errorf("Expected an unknown locale to be an error")
+	}
+}
+
+func TestLocaleMilitary(t *testing.T) {
+	tests := []struct {
+		name     string
+		military bool
+	}{
+		{"", false},
+		{"C", false},
+		{"en", false},
+		{"en_US.UTF-8", false},
+		{"en_AU.UTF-8", false},
+		{"en_GB.UTF-8", true},
+		{"en_IE", true},
+		{"fr_FR.UTF-8", true},
+		{"fr_CA.UTF-8", true},
+		{"ja_JP.UTF-8", true},
+	}
+	for _, test := range tests {
+		l, err := LoadLocale(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.Military != test.military {
+			t.Errorf("Expected 24-hour time %v in locale %q, but got %v", test.military, test.name, l.Military)
+		}
+	}
+}
+
+func TestLoadConfigMilitary(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"LC_ALL", "LC_TIME", "LANG", "TZ_LIST", "TZ_PROFILE"} {
+		t.Setenv(name, "")
+	}
+	tests := []struct {
+		env      string
+		config   string
+		military bool
+	}{
+		{"", "", false},
+		{"en_US.UTF-8", "", false},
+		{"en_GB.UTF-8", "", true},
+		{"en_GB.UTF-8", "military = false\n", false},
+		{"en_US.UTF-8", "military = true\n", true},
+		{"en_US.UTF-8", "locale = \"de\"\n", true},
+		{"nl_NL.UTF-8", "", true},
+		{"pt_BR", "", true},
+		{"nl_NL.UTF-8", "military = false\n", false},
+	}
+	for i, test := range tests {
+		tomlPath := filepath.Join(dir, fmt.Sprintf("conf%d.toml", i))
+		os.WriteFile(tomlPath, []byte(test.config), 0644)
+		t.Setenv("LANG", test.env)
+		config, err := LoadConfig(tomlPath, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *config.Military != test.military {
+			t.Errorf("Expected 24-hour time %v with LANG=%s and config %q, but got %v", test.military, test.env, test.config, *config.Military)
+		}
+	}
+}
@@ -39,11 +39,16 @@ var (
 	hasDarkBackground = termenv.HasDarkBackground()
 )
 
+// WatchInterval is how often the clock refreshes in watch mode, when not
+// showing seconds. It divides a minute, so that ticks still land on the
+// minute, and is short enough to notice clock jumps quickly.
+const WatchInterval = 5 * time.Second
+
 type tickMsg time.Time
 
-// Send a tickMsg every minute, on the minute.
-func tick() tea.Cmd {
-	return tea.Every(time.Minute, func(t time.Time) tea.Msg {
+// Send a tickMsg every interval, aligned on the system clock.
+func tick(interval time.Duration) tea.Cmd {
+	return tea.Every(interval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -73,17 +78,50 @@ func openInTimeAndDateDotCom(t time.Time) error {
 }
 
 type model struct {
-	zones       []*Zone
-	keymaps     Keymaps
-	clock       Clock
-	highlighted int // 0 == none, else row number indexed from 1
-	showDates   bool
-	interactive bool
-	isMilitary  bool
-	watch       bool
-	showHelp    bool
-	formatStyle FormatStyle
-	zoneStyle   ZoneStyle
+	zones            []*Zone
+	deadlines        []*Deadline
+	alarms           []*Alarm
+	alarmCommand     string
+	minuteStep       int          // Minutes moved by the minute keys
+	header           string       // Template of the header, or "" for the default
+	hideHeader       bool         // From an empty header template
+	profile          string       // Active profile, if any
+	bands            []Band       // Times of day of the timelines
+	holidays         []string     // Days off in every zone, as "2006-01-02"
+	ringing          map[int]bool // Rows of zones with a ringing alarm
+	bell             bool         // Ring the terminal bell in the next view only
+	accessible       bool         // Describe zones in plain text
+	announcement     string       // Last change, in accessible mode
+	keymaps          Keymaps
+	clock            Clock
+	history          History
+	bookmarks        []Bookmark
+	stateFile        string
+	highlighted      int // 0 == none, else row number indexed from 1
+	message          string
+	prompt           *Prompt
+	showDates        bool
+	showDST          bool
+	showSun          bool // Daylight in the timelines, and sunrise and sunset
+	showBookmarks    bool
+	selectedBookmark int
+	showCalendar     bool
+	showBigClock     bool
+	calendarDate     time.Time // Selected in the calendar, in its zone
+	interactive      bool
+	isMilitary       bool
+	militaryZones    map[*Zone]bool // 24-hour time toggled in a zone
+	watch            bool
+	showSeconds      bool
+	lastTick         time.Time
+	showHelp         bool
+	formats          []Format
+	formatStyle      FormatStyle
+	zoneStyle        ZoneStyle
+	grid             GridResolution
+	layout           Layout
+	termWidth        int // Last reported by bubbletea, 0 until then
+	termHeight       int
 }
 
 func (m model) Init() tea.Cmd {
@@ -92,8 +130,34 @@ func (m model) Init() tea.Cmd {
 		return tea.Quit
 	}
 
-	// Fire initial tick command to begin receiving ticks on the minute.
-	return tick()
+	// Fire initial tick command to begin receiving ticks.
+	return tick(m.tickInterval())
+}
+
+func (m model) tickInterval() time.Duration {
+	if m.showSeconds {
+		return time.Second
+	}
+	return WatchInterval
+}
+
+// Catch up with a jump of the wall clock, after a suspend or an NTP step:
+// a clock following real time moves to now, even outside watch mode, and
+// repeating alarms are rescheduled when the wall clock went back, so that
+// they don't wait for a later occurrence. Alarms the clock jumped over
+// still ring, on this tick, and the next tick aligns on the new time.
+func (m *model) resync(now time.Time, drift time.Duration) {
+	logger.Printf("Clock jumped by %v, resynchronising", drift)
+	if m.clock.isRealTime {
+		m.clock = *NewClockNow()
+	}
+	if drift < 0 {
+		for _, alarm := range m.alarms {
+			if alarm.Days != nil {
+				alarm.Next = alarm.nextAfter(now)
+			}
+		}
+	}
 }
 
 func match(input string, options []string) bool {
@@ -101,9 +165,32 @@ func match(input string, options []string) bool {
 }
 
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Only the view right after an alarm rang rings the bell, once
+	m.bell = false
+	if m.accessible {
+		defer m.announce(m.clock, m.highlighted)
+	}
+
 	switch msg := msg.(type) {
 
 	case tea.KeyMsg:
+		if m.prompt != nil {
+			if m.prompt.Update(m, msg) {
+				m.prompt = nil
+			}
+			return m, nil
+		}
+		m.message = ""
+		m.ringing = nil
+
+		if m.showBookmarks && m.updateBookmarks(msg) {
+			return m, nil
+		}
+		if m.showCalendar && m.updateCalendar(msg) {
+			return m, nil
+		}
+
+		previousClock := m.clock
 		key := msg.String()
 		switch {
 
@@ -111,10 +198,10 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 
 		case match(key, m.keymaps.PrevMinute):
-			m.clock.AddMinutes(-1)
+			m.clock.AddMinuteSteps(m.minuteStep, -1)
 
 		case match(key, m.keymaps.NextMinute):
-			m.clock.AddMinutes(1)
+			m.clock.AddMinuteSteps(m.minuteStep, 1)
 
 		case match(key, m.keymaps.ZeroMinute):
 			m.clock = *NewClockTime(time.Date(
@@ -128,6 +215,12 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.clock.t.Location(),
 			))
 
+		case match(key, m.keymaps.SnapQuarter):
+			m.clock.Snap(15)
+
+		case match(key, m.keymaps.SnapHalf):
+			m.clock.Snap(30)
+
 		case match(key, m.keymaps.PrevHour):
 			m.clock.AddHours(-1)
 
@@ -146,6 +239,30 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case match(key, m.keymaps.NextWeek):
 			m.clock.AddDays(7)
 
+		case match(key, m.keymaps.PrevMonth):
+			m.clock.AddMonths(-1)
+
+		case match(key, m.keymaps.NextMonth):
+			m.clock.AddMonths(1)
+
+		case match(key, m.keymaps.PrevYear):
+			m.clock.AddYears(-1)
+
+		case match(key, m.keymaps.NextYear):
+			m.clock.AddYears(1)
+
+		case match(key, m.keymaps.StartOfDay):
+			m.clock.StartOfDay()
+
+		case match(key, m.keymaps.StartOfWeek):
+			m.clock.StartOfWeek()
+
+		case match(key, m.keymaps.NextWeekday):
+			m.clock.NextWeekday()
+
+		case match(key, m.keymaps.FirstWeekday):
+			m.clock.FirstWeekday()
+
 		case match(key, m.keymaps.PrevLine):
 			modulo := len(m.zones) + 1
 			m.highlighted = (m.highlighted - 1 + modulo) % modulo
@@ -155,10 +272,27 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.highlighted = (m.highlighted + 1) % modulo
 
 		case match(key, m.keymaps.NextFStyle):
-			m.formatStyle = m.formatStyle.next()
+			m.formatStyle = m.formatStyle.next(len(m.formatList()))
 
 		case match(key, m.keymaps.PrevFStyle):
-			m.formatStyle = m.formatStyle.previous()
+			m.formatStyle = m.formatStyle.previous(len(m.formatList()))
+
+		case match(key, m.keymaps.ToggleMilitary):
+			m.toggleMilitary()
+
+		case match(key, m.keymaps.BigClock):
+			m.showBigClock = !m.showBigClock
+			m.showBookmarks = false
+			m.showCalendar = false
+
+		case match(key, m.keymaps.ToggleSun):
+			m.showSun = !m.showSun
+
+		case match(key, m.keymaps.NextLayout):
+			m.layout = m.layout.next()
+
+		case match(key, m.keymaps.ToggleGrid):
+			m.grid = m.grid.next()
 
 		case match(key, m.keymaps.PrevZStyle):
 			m.zoneStyle = m.zoneStyle.previous()
@@ -175,15 +309,86 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case match(key, m.keymaps.ToggleDate):
 			m.showDates = !m.showDates
 
+		case match(key, m.keymaps.PrevTransition):
+			m.jumpToTransition(false)
+
+		case match(key, m.keymaps.NextTransition):
+			m.jumpToTransition(true)
+
+		case match(key, m.keymaps.ToggleDST):
+			m.showDST = !m.showDST
+
+		case match(key, m.keymaps.AddAlarm):
+			m.prompt = &Prompt{Label: "New alarm", Submit: (*model).addAlarm}
+
+		case match(key, m.keymaps.AddBookmark):
+			m.prompt = &Prompt{Label: "Bookmark name", Submit: (*model).addBookmark}
+
+		// The big clock hides the overlays: don't open them under it
+		case match(key, m.keymaps.Bookmarks) && !m.showBigClock:
+			m.showBookmarks = true
+			m.selectedBookmark = 0
+
+		case match(key, m.keymaps.Calendar) && !m.showBigClock:
+			m.openCalendar()
+
+		case match(key, m.keymaps.Undo):
+			if clock, ok := m.history.Undo(m.clock); ok {
+				m.clock = clock
+			} else {
+				m.message = "Nothing to undo"
+			}
+			return m, nil
+
+		case match(key, m.keymaps.Redo):
+			if clock, ok := m.history.Redo(m.clock); ok {
+				m.clock = clock
+			} else {
+				m.message = "Nothing to redo"
+			}
+			return m, nil
+
 		case match(key, m.keymaps.Help):
 			m.showHelp = !m.showHelp
 		}
 
+		if !m.clock.t.Equal(previousClock.t) {
+			m.history.Push(previousClock)
+		}
+
+	case tea.MouseMsg:
+		if m.prompt != nil {
+			return m, nil
+		}
+		previousClock := m.clock
+		m.updateMouse(msg)
+		if !m.clock.t.Equal(previousClock.t) {
+			m.history.Push(previousClock)
+		}
+
+	case tea.WindowSizeMsg:
+		m.termWidth = msg.Width
+		m.termHeight = msg.Height
+
 	case tickMsg:
+		now := time.Time(msg)
+		if ClockJumped(m.lastTick, now) {
+			m.resync(now, WallClockDrift(m.lastTick, now))
+		}
+		m.lastTick = now
+
 		if m.watch && m.clock.isRealTime {
 			m.clock = *NewClockNow()
 		}
-		return m, tick()
+
+		cmds := []tea.Cmd{tick(m.tickInterval())}
+		for _, alarm := range m.alarms {
+			at := alarm.Next
+			if alarm.Due(now) {
+				cmds = append(cmds, m.ring(alarm, at))
+			}
+		}
+		return m, tea.Batch(cmds...)
 	}
 	return m, nil
 }
@@ -192,16 +397,43 @@ func main() {
 	SetupLogger()
 	logger.Println("Startup")
 
+	// A subcommand comes first, so that "tz -list dst" searches for "dst"
+	if len(os.Args) > 1 && os.Args[1] == "dst" {
+		os.Exit(dstCommand(os.Args[2:]))
+	}
+
 	exitQuick := flag.Bool("q", false, "exit immediately")
 	showVersion := flag.Bool("v", false, "show version")
 	when := flag.Int64("when", 0, "time in seconds since unix epoch (disables -w)")
 	doSearch := flag.Bool("list", false, "[filter] list or search zones by name")
 	military := flag.Bool("m", false, "use 24-hour time")
-	watch := flag.Bool("w", false, "watch live, set time to now every minute")
+	watch := flag.Bool("w", false, "watch live, set time to now every 5 seconds, or every second with -s")
+	zoneInfoPath := flag.String("zoneinfo", "", "load zones from a zoneinfo directory or zip archive")
+	seconds := flag.Bool("s", false, "show seconds, and update every second with -w")
+	format := flag.String("format", "", "name of the initial format style, e.g. iso")
+	accessible := flag.Bool("a", false, "accessible mode: describe zones in plain text, without colors or emoji")
+	big := flag.Bool("big", false, "show the big clock of each zone in turn, e.g. on a wall display (implies -w)")
+	until := flag.String("until", "", "count down to a deadline, e.g. \"2024-06-01 23:59 AoE\" (implies -w)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: tz [flags] [zones...]\n       tz dst [flags] [zones...]\n\n")
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
+	if *zoneInfoPath != "" {
+		if err := SetZoneInfo(*zoneInfoPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Config error: %s\n", err)
+			os.Exit(2)
+		}
+	}
+
 	if *showVersion == true {
+		// Read the config file for its zoneinfo
+		if _, err := LoadDefaultConfig(nil); err != nil {
+			fmt.Fprintf(os.Stderr, "Config error: %s\n", err)
+		}
 		fmt.Printf("tz %s\n", CurrentVersion)
+		fmt.Printf("tzdata: %s\n", TzDataSource())
 		os.Exit(0)
 	}
 
@@ -220,16 +452,63 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Config error: %s\n", err)
 		os.Exit(2)
 	}
+	theme = *config.Theme
+	locale = config.Locale
+	if *accessible || config.Accessible {
+		term = termenv.Ascii
+	}
+
+	stateFile, state := OpenState()
 
 	var initialModel = model{
-		zones:      config.Zones,
-		keymaps:    config.Keymaps,
-		clock:      *NewClockNow(),
-		showDates:  false,
-		isMilitary: *military,
-		watch:      *watch,
-		showHelp:   false,
-		zoneStyle:  AbbreviationZoneStyle,
+		zones:        config.Zones,
+		deadlines:    config.Deadlines,
+		alarms:       config.Alarms,
+		alarmCommand: config.AlarmCommand,
+		minuteStep:   config.MinuteStep,
+		header:       config.Header,
+		hideHeader:   config.HideHeader,
+		profile:      config.Profile,
+		bands:        config.Bands,
+		formats:      config.Formats,
+		holidays:     config.Holidays,
+		keymaps:      config.Keymaps,
+		bookmarks:    state.Bookmarks,
+		stateFile:    stateFile,
+		clock:        *NewClockNow(),
+		showDates:    false,
+		isMilitary:   *military || *config.Military,
+		watch:        *watch,
+		showSeconds:  *seconds,
+		showHelp:     false,
+		zoneStyle:    AbbreviationZoneStyle,
+		accessible:   *accessible || config.Accessible,
+	}
+
+	if *format != "" {
+		initialModel.formatStyle, err = FindFormat(initialModel.formatList(), *format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Format error: %s\n", err)
+			os.Exit(2)
+		}
+	}
+
+	if *until != "" {
+		deadline, err := ParseDeadline("", *until, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Deadline error: %s\n", err)
+			os.Exit(2)
+		}
+		initialModel.deadlines = append(initialModel.deadlines, deadline)
+	}
+	// Keep countdowns live, whether deadlines come from -until or the config
+	if len(initialModel.deadlines) > 0 {
+		initialModel.watch = true
+	}
+
+	if *big {
+		initialModel.showBigClock = true
+		initialModel.watch = true
 	}
 
 	if *when != 0 {
@@ -238,7 +517,11 @@ func main() {
 
 	initialModel.interactive = !*exitQuick && isatty.IsTerminal(os.Stdout.Fd())
 
-	p := tea.NewProgram(&initialModel)
+	var options []tea.ProgramOption
+	if initialModel.interactive && !config.DisableMouse && !initialModel.accessible {
+		options = append(options, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(&initialModel, options...)
 	if err := p.Start(); err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 		os.Exit(1)
@@ -19,6 +19,7 @@ package main
 import (
 	"os"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -230,6 +231,34 @@ func TestUpdateQuitMsg(t *testing.T) {
 	// calling it is getting into internal territory.
 }
 
+func TestUpdateTickMsg(t *testing.T) {
+	m := model{
+		zones:       DefaultZones,
+		keymaps:     DefaultKeymaps,
+		clock:       *NewClockNow(),
+		watch:       true,
+		showSeconds: true,
+	}
+	before := m.clock.Time()
+	tick := time.Now()
+	_, cmd := m.Update(tickMsg(tick))
+	if cmd == nil {
+		t.Fatal("Expected another tick Cmd, but got nil")
+	}
+	if !m.lastTick.Equal(tick) {
+		t.Errorf("Expected last tick at %v, but got %v", tick, m.lastTick)
+	}
+	if !m.clock.isRealTime || m.clock.Time().Before(before) {
+		t.Errorf("Expected watched clock to be refreshed, but got %v", m.clock.Time())
+	}
+
+	m.clock = *NewClockTime(utcMinuteAfterMidnightTime)
+	m.Update(tickMsg(time.Now()))
+	if !m.clock.Time().Equal(utcMinuteAfterMidnightTime) {
+		t.Errorf("Expected time-travelling clock to stay put, but got %v", m.clock.Time())
+	}
+}
+
 func TestMilitaryTime(t *testing.T) {
 	testDataFile := "testdata/main/test-military-time.txt"
 	testData, err := txtar.ParseFile(testDataFile)
@@ -263,3 +292,338 @@ func TestMilitaryTime(t *testing.T) {
 		t.Errorf("Expected military time of %s, but got %s", expected, observed)
 	}
 }
+
+func TestUpdateWindowSizeMsg(t *testing.T) {
+	m := model{
+		zones:   DefaultZones,
+		keymaps: DefaultKeymaps,
+		clock:   *NewClockTime(utcMinuteAfterMidnightTime),
+	}
+	m.Update(tea.WindowSizeMsg{Width: 40, Height: 20})
+	if m.termWidth != 40 || m.termHeight != 20 {
+		t.Errorf("Expected a 40x20 terminal, but got %vx%v", m.termWidth, m.termHeight)
+	}
+	if m.viewWidth() != 40 {
+		t.Errorf("Expected the view to fit 40 columns, but got %v", m.viewWidth())
+	}
+	if m.currentLayout(m.viewWidth()) != CompactLayout {
+		t.Errorf("Expected the compact layout in 40 columns")
+	}
+
+	m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	if m.viewWidth() != MaximumZoneHeaderColumns {
+		t.Errorf("Expected the view to be at most %v columns, but got %v", MaximumZoneHeaderColumns, m.viewWidth())
+	}
+	if m.currentLayout(m.viewWidth()) != GridLayout {
+		t.Errorf("Expected the grid layout in 200 columns")
+	}
+}
+
+func TestUpdateMouseMsg(t *testing.T) {
+	m := model{
+		zones:     DefaultZones,
+		keymaps:   DefaultKeymaps,
+		clock:     *NewClockTime(utcMinuteAfterMidnightTime),
+		termWidth: MaximumZoneHeaderColumns,
+	}
+	lines := strings.Split(stripAnsiControlSequences(m.View()), "\n")
+	header := m.headerLines() + len(gridZoneHeader(&m, m.zones[0], m.viewWidth(), utcMinuteAfterMidnightTime))
+	if !strings.Contains(lines[header-1], m.zones[0].Name) {
+		t.Fatalf("Expected the first zone header on line %v, but got %q", header-1, lines[header-1])
+	}
+
+	// Click the third hour of the second zone
+	y := header + 2 + len(gridZoneHeader(&m, m.zones[1], m.viewWidth(), utcMinuteAfterMidnightTime))
+	m.Update(tea.MouseMsg{X: 2 + 2*ColumnWidth, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	if h := m.clock.t.Hour(); h != 2 {
+		t.Errorf("Expected clicking the third hour to move the clock to 2, but got %v", h)
+	}
+	if m.clock.isRealTime {
+		t.Errorf("Expected the clock to stop following the current time")
+	}
+	if m.clock.t.Minute() != utcMinuteAfterMidnightTime.Minute() {
+		t.Errorf("Expected clicking to keep the minutes, but got %v", m.clock.t.Minute())
+	}
+
+	// Click the header of the second zone
+	m.Update(tea.MouseMsg{X: 4, Y: y - 1, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	if m.highlighted != 2 {
+		t.Errorf("Expected clicking a zone header to highlight it, but got %v", m.highlighted)
+	}
+
+	// Clicks above the zones, and releases, change nothing
+	m.Update(tea.MouseMsg{X: 4, Y: 1, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	m.Update(tea.MouseMsg{X: 2, Y: y, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+	if h := m.clock.t.Hour(); h != 2 || m.highlighted != 2 {
+		t.Errorf("Expected hour 2 with zone 2 highlighted, but got %v and %v", h, m.highlighted)
+	}
+
+	// The wheel moves by hours, or days with shift
+	m.Update(tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	if h := m.clock.t.Hour(); h != 3 {
+		t.Errorf("Expected the wheel to move the clock to 3, but got %v", h)
+	}
+	m.Update(tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelUp, Shift: true})
+	if d := m.clock.t.Day(); d != utcMinuteAfterMidnightTime.Day()-1 {
+		t.Errorf("Expected shift and the wheel to move the clock a day back, but got day %v", d)
+	}
+
+	// Mouse moves are undone like keys
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if d := m.clock.t.Day(); d != utcMinuteAfterMidnightTime.Day() {
+		t.Errorf("Expected undo to move the clock back to day %v, but got %v", utcMinuteAfterMidnightTime.Day(), d)
+	}
+}
+
+func TestMouseVerticalLayout(t *testing.T) {
+	m := model{
+		zones:     DefaultZones,
+		keymaps:   DefaultKeymaps,
+		clock:     *NewClockTime(utcMinuteAfterMidnightTime),
+		termWidth: MaximumZoneHeaderColumns,
+		layout:    VerticalLayout,
+	}
+	tl := m.verticalTimelines()
+
+	// Click the second row of the second column
+	m.Update(tea.MouseMsg{X: VerticalColumnWidth + 4, Y: m.headerLines() + 3, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	want := utcMinuteAfterMidnightTime.Add(time.Duration(tl.first + 1 - tl.cursor) * tl.step)
+	if !m.clock.t.Equal(want) {
+		t.Errorf("Expected the clock at %v, but got %v", want, m.clock.t)
+	}
+
+	m.Update(tea.MouseMsg{X: VerticalColumnWidth + 4, Y: m.headerLines(), Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	if m.highlighted != 2 {
+		t.Errorf("Expected clicking a zone name to highlight it, but got %v", m.highlighted)
+	}
+}
+
+func TestMouseWeekOverview(t *testing.T) {
+	m := model{
+		zones:     DefaultZones,
+		keymaps:   DefaultKeymaps,
+		clock:     *NewClockTime(utcMinuteAfterMidnightTime),
+		termWidth: MaximumZoneHeaderColumns,
+		layout:    WeekLayout,
+	}
+
+	// Click 10AM on Wednesday, the third row of the second zone
+	y := m.headerLines() + 1 + 8 + 1 + 2
+	x := 2 + WeekDayLabelWidth + 10*weekCellWidth(m.viewWidth())
+	m.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	want := time.Date(2017, 11, 1, 10, 1, 0, 0, time.UTC)
+	if !m.clock.t.Equal(want) {
+		t.Errorf("Expected the clock at %v, but got %v", want, m.clock.t)
+	}
+
+	m.Update(tea.MouseMsg{X: 4, Y: m.headerLines() + 1 + 8, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	if m.highlighted != 2 {
+		t.Errorf("Expected clicking a zone name to highlight it, but got %v", m.highlighted)
+	}
+}
+
+func TestMouseWorldMap(t *testing.T) {
+	m := model{
+		zones:     DefaultZones,
+		keymaps:   DefaultKeymaps,
+		clock:     *NewClockTime(utcMinuteAfterMidnightTime),
+		termWidth: MaximumZoneHeaderColumns,
+		layout:    MapLayout,
+	}
+
+	// Clicking the map leaves the clock alone, and the list under it
+	// highlights zones.
+	m.Update(tea.MouseMsg{X: 10, Y: m.headerLines() + 2, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	if !m.clock.t.Equal(utcMinuteAfterMidnightTime) || m.highlighted != 0 {
+		t.Errorf("Expected clicking the map to do nothing, but got %v and %v", m.clock.t, m.highlighted)
+	}
+	_, rows := mapSize(m.viewWidth())
+	m.Update(tea.MouseMsg{X: 4, Y: m.headerLines() + rows + 2, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	if m.highlighted != 2 {
+		t.Errorf("Expected clicking a zone name to highlight it, but got %v", m.highlighted)
+	}
+}
+
+func TestUpdateCalendar(t *testing.T) {
+	kiritimati, err := LoadLocation("Pacific/Kiritimati")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := model{
+		zones:       []*Zone{{Loc: time.UTC, Name: "UTC"}, {Loc: kiritimati, Name: "Kiritimati"}},
+		keymaps:     DefaultKeymaps,
+		clock:       *NewClockTime(time.Date(2024, 10, 31, 12, 0, 0, 0, time.UTC)),
+		highlighted: 2,
+	}
+	keys := func(runes ...rune) {
+		for _, r := range runes {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	keys('c')
+	if !m.showCalendar || m.calendarDate.Format(time.DateOnly) != "2024-11-01" {
+		t.Fatalf("Expected the calendar on Nov 1st in Kiritimati, but got %v", m.calendarDate)
+	}
+
+	// A day and a week forward, then pick the date
+	keys('l', 'j')
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	want := time.Date(2024, 11, 9, 2, 0, 0, 0, kiritimati)
+	if m.showCalendar || !m.clock.t.Equal(want) {
+		t.Errorf("Expected the clock at %v with the calendar closed, but got %v", want, m.clock.t)
+	}
+	if m.highlighted != 2 {
+		t.Errorf("Expected the calendar keys to keep the highlighted zone, but got %v", m.highlighted)
+	}
+
+	// Closing the calendar leaves the clock alone
+	keys('c', 'l', 'c')
+	if m.showCalendar || !m.clock.t.Equal(want) {
+		t.Errorf("Expected the clock to stay at %v, but got %v", want, m.clock.t)
+	}
+
+	keys('u')
+	if !m.clock.t.Equal(time.Date(2024, 10, 31, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected undo to go back before the picked date, but got %v", m.clock.t)
+	}
+}
+
+func TestToggleMilitary(t *testing.T) {
+	military := true
+	newYork := &Zone{Loc: time.UTC, DbName: "UTC", Name: "New York"}
+	paris := &Zone{Loc: time.UTC, DbName: "UTC", Name: "Paris", Military: &military}
+	m := model{
+		zones:   []*Zone{newYork, paris},
+		keymaps: DefaultKeymaps,
+		clock:   *NewClockTime(time.Date(2024, 10, 30, 15, 4, 0, 0, time.UTC)),
+	}
+	formats := func() string {
+		return m.formatZoneTime(newYork, m.clock.t) + " / " + m.formatZoneTime(paris, m.clock.t)
+	}
+	toggle := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}}
+
+	expected := "3:04PM, Wed Oct 30, 2024 / 15:04, Wed Oct 30, 2024"
+	if observed := formats(); observed != expected {
+		t.Errorf("Expected a zone to override the 12-hour default: %q, but got %q", expected, observed)
+	}
+
+	// Without a highlighted zone, the key toggles the default
+	m.Update(toggle)
+	expected = "15:04, Wed Oct 30, 2024 / 15:04, Wed Oct 30, 2024"
+	if observed := formats(); observed != expected {
+		t.Errorf("Expected %q, but got %q", expected, observed)
+	}
+
+	// With a highlighted zone, only that zone's
+	m.highlighted = 2
+	m.Update(toggle)
+	expected = "15:04, Wed Oct 30, 2024 / 3:04PM, Wed Oct 30, 2024"
+	if observed := formats(); observed != expected {
+		t.Errorf("Expected %q, but got %q", expected, observed)
+	}
+	if paris.Military != &military || !military {
+		t.Errorf("Expected toggling a zone to leave its config alone")
+	}
+	other := model{zones: []*Zone{paris}}
+	if !other.zoneMilitary(paris) {
+		t.Errorf("Expected toggling a zone to leave other models alone")
+	}
+}
+
+func TestBigClockZoneBeforeFirstTick(t *testing.T) {
+	m := model{
+		zones:        LoadDstTestZones(t)[:7],
+		keymaps:      DefaultKeymaps,
+		clock:        *NewClockTime(utcMinuteAfterMidnightTime),
+		showBigClock: true,
+	}
+	zone := m.bigClockZone()
+	if !slices.Contains(m.zones, zone) {
+		t.Errorf("Expected one of the zones, but got %v", zone)
+	}
+	m.View()
+}
+
+func TestBigClockZone(t *testing.T) {
+	m := model{
+		zones:    DefaultZones,
+		keymaps:  DefaultKeymaps,
+		clock:    *NewClockTime(utcMinuteAfterMidnightTime),
+		lastTick: time.Unix(0, 0),
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if !m.showBigClock {
+		t.Fatal("Expected the big clock to show")
+	}
+
+	// Without a highlighted zone, zones take turns
+	if zone := m.bigClockZone(); zone != DefaultZones[0] {
+		t.Errorf("Expected the first zone, but got %v", zone)
+	}
+	m.lastTick = m.lastTick.Add(BigClockRotation)
+	if zone := m.bigClockZone(); zone != DefaultZones[1] {
+		t.Errorf("Expected the second zone after %v, but got %v", BigClockRotation, zone)
+	}
+	m.lastTick = m.lastTick.Add(BigClockRotation)
+	if zone := m.bigClockZone(); zone != DefaultZones[0] {
+		t.Errorf("Expected the first zone again, but got %v", zone)
+	}
+
+	m.highlighted = 2
+	m.lastTick = m.lastTick.Add(BigClockRotation)
+	if zone := m.bigClockZone(); zone != DefaultZones[1] {
+		t.Errorf("Expected the highlighted zone, but got %v", zone)
+	}
+
+	// Overlays don't open under the big clock
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if m.showBookmarks || m.showCalendar {
+		t.Errorf("Expected no overlays under the big clock, but got bookmarks %v and calendar %v", m.showBookmarks, m.showCalendar)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if m.showBigClock {
+		t.Error("Expected the big clock to hide")
+	}
+
+	// Showing the big clock closes the overlays
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if !m.showBigClock || m.showCalendar {
+		t.Errorf("Expected the big clock without the calendar, but got %v and %v", m.showBigClock, m.showCalendar)
+	}
+}
+
+func TestMouseAccessible(t *testing.T) {
+	m := model{
+		zones:      DefaultZones,
+		keymaps:    DefaultKeymaps,
+		clock:      *NewClockTime(utcMinuteAfterMidnightTime),
+		termWidth:  MaximumZoneHeaderColumns,
+		accessible: true,
+	}
+	for y := 0; y < 10; y++ {
+		m.Update(tea.MouseMsg{X: 10, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	}
+	if !m.clock.t.Equal(utcMinuteAfterMidnightTime) || m.highlighted != 0 {
+		t.Errorf("Expected clicks to do nothing in accessible mode, but got %v and %v", m.clock.t, m.highlighted)
+	}
+}
+
+func TestMouseBigClock(t *testing.T) {
+	m := model{
+		zones:        DefaultZones,
+		keymaps:      DefaultKeymaps,
+		clock:        *NewClockTime(utcMinuteAfterMidnightTime),
+		termWidth:    MaximumZoneHeaderColumns,
+		showBigClock: true,
+	}
+	for y := 0; y < 10; y++ {
+		m.Update(tea.MouseMsg{X: 10, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	}
+	if !m.clock.t.Equal(utcMinuteAfterMidnightTime) || m.highlighted != 0 {
+		t.Errorf("Expected clicks on the big clock to do nothing, but got %v and %v", m.clock.t, m.highlighted)
+	}
+}
@@ -0,0 +1,155 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// What is on screen at some position: the header of a zone, or a column
+// of its timeline.
+type hit struct {
+	zone   int // Index of the zone, or -1 for none
+	column int // Column of the timelines, or hours from the start of the week in the week overview, or -1 for the header
+}
+
+var noHit = hit{zone: -1, column: -1}
+
+// Find what is at (x, y) on screen, in the current layout. The plain
+// text of the accessible mode, and the big clock, have nothing to click.
+func (m *model) hitTest(x int, y int) hit {
+	width := m.viewWidth()
+	y -= m.headerLines()
+	if y < 0 || len(m.zones) == 0 || m.accessible || m.showBigClock {
+		return noHit
+	}
+
+	switch m.currentLayout(width) {
+	case CompactLayout:
+		if y < len(m.zones) {
+			return hit{zone: y, column: -1}
+		}
+
+	case WeekLayout:
+		cellWidth := weekCellWidth(width)
+		hour := (x - 2 - WeekDayLabelWidth) / cellWidth
+		if x < 2+WeekDayLabelWidth || hour >= 24 {
+			hour = -1
+		}
+		// Skip the hours, then each zone has a header and a row per day,
+		// followed by the rows of the overlap.
+		y--
+		zone, row := y/8, y%8-1
+		switch {
+		case y < 0 || zone > len(m.zones) || (zone == len(m.zones) && len(m.zones) < 2):
+			return noHit
+		case row < 0 && zone < len(m.zones):
+			return hit{zone: zone, column: -1}
+		case row < 0 || hour < 0:
+			return noHit
+		case zone == len(m.zones):
+			zone = -1
+		}
+		return hit{zone: zone, column: row*24 + hour}
+
+	case MapLayout:
+		// Skip the map and the line after it, to the zones
+		_, rows := mapSize(width)
+		if zone := y - rows - 1; zone >= 0 && zone < len(m.zones) {
+			return hit{zone: zone, column: -1}
+		}
+
+	case VerticalLayout:
+		zone := x / VerticalColumnWidth
+		if zone >= len(m.zones) {
+			return noHit
+		}
+		header := m.verticalHeaderLines()
+		if y < header {
+			return hit{zone: zone, column: -1}
+		}
+		tl := m.verticalTimelines()
+		if column := tl.first + y - header; column < tl.last {
+			return hit{zone: zone, column: column}
+		}
+
+	default:
+		tl := m.timelines((width - 2) / ColumnWidth)
+		for i, zone := range m.zones {
+			cells := tl.cells(zone)
+			header := len(gridZoneHeader(m, zone, width, cells[0].time))
+			switch {
+			case y < header:
+				return hit{zone: i, column: -1}
+			case y == header:
+				column := tl.first + (x-2)/ColumnWidth
+				if x < 2 || column >= tl.last {
+					return noHit
+				}
+				return hit{zone: i, column: column}
+			}
+			// Skip the header, hours and dates of the zone
+			y -= header + 2
+			if y < 0 {
+				return noHit
+			}
+		}
+	}
+	return noHit
+}
+
+// Handle the mouse: clicking a timeline moves the clock to that column,
+// clicking a zone header highlights the zone, and the wheel moves the
+// clock by hours, or by days with shift or ctrl.
+func (m *model) updateMouse(msg tea.MouseMsg) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		n := 1
+		if msg.Button == tea.MouseButtonWheelUp {
+			n = -1
+		}
+		if msg.Shift || msg.Ctrl {
+			m.clock.AddDays(n)
+		} else {
+			m.clock.AddHours(n)
+		}
+
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return
+		}
+		target := m.hitTest(msg.X, msg.Y)
+		if target == noHit {
+			return
+		}
+		if target.column < 0 {
+			m.highlighted = target.zone + 1
+			return
+		}
+		if m.currentLayout(m.viewWidth()) == WeekLayout {
+			t := weekCell(m.weekStart(), target.column/24, target.column%24)
+			m.clock = *NewClockTime(t.Add(time.Duration(m.clock.t.Minute()) * time.Minute))
+			return
+		}
+		tl := m.timelines(0)
+		if target.column != tl.cursor {
+			m.clock = *NewClockTime(m.clock.t.Add(time.Duration(target.column-tl.cursor) * tl.step))
+		}
+	}
+}
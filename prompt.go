@@ -0,0 +1,60 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Prompt reads a line of text from the keyboard, in the status bar.
+type Prompt struct {
+	Label  string
+	Input  string
+	Submit func(m *model, input string) error
+}
+
+// Update the prompt with a key press, and report whether it is done,
+// either submitted with enter, or cancelled with escape.
+func (p *Prompt) Update(m *model, msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyEnter:
+		if err := p.Submit(m, p.Input); err != nil {
+			m.message = err.Error()
+		}
+		return true
+
+	case tea.KeyEsc, tea.KeyCtrlC:
+		return true
+
+	case tea.KeyBackspace:
+		if runes := []rune(p.Input); len(runes) > 0 {
+			p.Input = string(runes[:len(runes)-1])
+		}
+
+	case tea.KeySpace:
+		p.Input += " "
+
+	case tea.KeyRunes:
+		p.Input += string(msg.Runes)
+	}
+	return false
+}
+
+// Render the prompt with a cursor.
+func (p Prompt) String() string {
+	return p.Label + ": " + p.Input + "█"
+}
@@ -0,0 +1,350 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/muesli/termenv"
+)
+
+// Principal locations of the tzdata zones, and links from their old names.
+//
+//go:embed tzdata/zone1970.tab
+var zone1970Tab string
+
+//go:embed tzdata/links.tab
+var linksTab string
+
+// Coordinates of a place, in degrees: north and east are positive.
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func (c Coordinates) String() string {
+	return fmt.Sprintf("%.2f,%.2f", c.Latitude, c.Longitude)
+}
+
+// CheckCoordinates validates a latitude and a longitude.
+func CheckCoordinates(c Coordinates) error {
+	if c.Latitude < -90 || c.Latitude > 90 {
+		return fmt.Errorf("invalid latitude %v", c.Latitude)
+	}
+	if c.Longitude < -180 || c.Longitude > 180 {
+		return fmt.Errorf("invalid longitude %v", c.Longitude)
+	}
+	return nil
+}
+
+var (
+	tzdataCoordinates     map[string]Coordinates
+	loadTzdataCoordinates sync.Once
+)
+
+// LookupCoordinates finds the principal location of a tzdata zone, by its
+// current or old name.
+func LookupCoordinates(name string) (Coordinates, bool) {
+	loadTzdataCoordinates.Do(func() {
+		tzdataCoordinates = parseZoneTab(zone1970Tab)
+		for _, link := range tabRows(linksTab) {
+			if c, ok := tzdataCoordinates[link[0]]; ok && len(link) > 1 {
+				tzdataCoordinates[link[1]] = c
+			}
+		}
+	})
+	c, ok := tzdataCoordinates[name]
+	return c, ok
+}
+
+// Rows of a tab separated file, without comments.
+func tabRows(data string) [][]string {
+	var rows [][]string
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rows = append(rows, strings.Split(line, "\t"))
+	}
+	return rows
+}
+
+// Parse the coordinates of zone1970.tab, by zone name.
+func parseZoneTab(data string) map[string]Coordinates {
+	coordinates := make(map[string]Coordinates)
+	for _, row := range tabRows(data) {
+		if len(row) < 3 {
+			continue
+		}
+		if c, err := parseISO6709(row[1]); err == nil {
+			coordinates[row[2]] = c
+		}
+	}
+	return coordinates
+}
+
+// Parse coordinates in the ISO 6709 sign-degrees-minutes-seconds format
+// of zone1970.tab: ±DDMM±DDDMM or ±DDMMSS±DDDMMSS.
+func parseISO6709(s string) (Coordinates, error) {
+	split := strings.IndexAny(s[1:], "+-") + 1
+	if split == 0 {
+		return Coordinates{}, fmt.Errorf("invalid coordinates %q", s)
+	}
+	latitude, err := parseISO6709Angle(s[:split], 2)
+	if err != nil {
+		return Coordinates{}, err
+	}
+	longitude, err := parseISO6709Angle(s[split:], 3)
+	if err != nil {
+		return Coordinates{}, err
+	}
+	return Coordinates{Latitude: latitude, Longitude: longitude}, nil
+}
+
+func parseISO6709Angle(s string, degreeDigits int) (float64, error) {
+	digits := s[1:]
+	if len(digits) != degreeDigits+2 && len(digits) != degreeDigits+4 {
+		return 0, fmt.Errorf("invalid angle %q", s)
+	}
+	// Degrees, minutes, and optional seconds
+	fields := []string{digits[:degreeDigits], digits[degreeDigits : degreeDigits+2], digits[degreeDigits+2:]}
+	var angle float64
+	for i, field := range fields {
+		if field == "" {
+			continue
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return 0, fmt.Errorf("invalid angle %q", s)
+		}
+		angle += float64(n) / math.Pow(60, float64(i))
+	}
+	if s[0] == '-' {
+		angle = -angle
+	}
+	return angle, nil
+}
+
+// Name of the local zone in tzdata, from $TZ or /etc/localtime, or "".
+func localZoneName() string {
+	if tz, ok := os.LookupEnv("TZ"); ok {
+		return strings.TrimPrefix(tz, ":")
+	}
+	target, err := filepath.EvalSymlinks("/etc/localtime")
+	if err != nil {
+		return ""
+	}
+	if _, name, found := strings.Cut(target, "zoneinfo/"); found {
+		return name
+	}
+	return ""
+}
+
+// Name of a location in tzdata, with the local zone resolved when known.
+func locationName(loc *time.Location) string {
+	name := loc.String()
+	if name == "Local" {
+		if local := localZoneName(); local != "" {
+			return local
+		}
+	}
+	return name
+}
+
+// Coordinates of a zone: configured, or else those of its tzdata zone.
+func (z *Zone) coordinates() (Coordinates, bool) {
+	if z.Coordinates != nil {
+		return *z.Coordinates, true
+	}
+	return LookupCoordinates(locationName(z.Loc))
+}
+
+// The sun is above the horizon when its center is higher than this, in
+// degrees, to account for refraction and its radius.
+const SunHorizon = -0.833
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// Elevation of the sun above the horizon at `t`, in degrees, from the low
+// precision formulas of the Astronomical Almanac, good to a minute or two
+// for sunrise and sunset.
+func sunElevation(t time.Time, c Coordinates) float64 {
+	d := float64(t.Unix())/86400 - 10957.5 // Days since 2000-01-01 12:00 UTC
+	g := radians(357.529 + 0.98560028*d)
+	q := 280.459 + 0.98564736*d
+	l := radians(q + 1.915*math.Sin(g) + 0.020*math.Sin(2*g))
+	e := radians(23.439 - 0.00000036*d)
+	rightAscension := math.Atan2(math.Cos(e)*math.Sin(l), math.Cos(l))
+	declination := math.Asin(math.Sin(e) * math.Sin(l))
+	siderealTime := 280.46061837 + 360.98564736629*d
+	hourAngle := radians(siderealTime+c.Longitude) - rightAscension
+	latitude := radians(c.Latitude)
+	return degrees(math.Asin(
+		math.Sin(latitude)*math.Sin(declination) +
+			math.Cos(latitude)*math.Cos(declination)*math.Cos(hourAngle)))
+}
+
+// Whether the sun is up at `t`.
+func sunUp(t time.Time, c Coordinates) bool {
+	return sunElevation(t, c) > SunHorizon
+}
+
+// SunDay holds the sunrise and sunset of a day, when they happen.
+type SunDay struct {
+	Rise time.Time // Zero when the sun doesn't rise that day
+	Set  time.Time // Zero when the sun doesn't set that day
+	Up   bool      // Whether the sun is up at noon, for polar days and nights
+}
+
+// Step of the search for sunrise and sunset, short enough to not miss
+// the few minutes of sun of the first day after a polar night.
+const sunSearchStep = 10 * time.Minute
+
+// SunDayAt computes the sunrise and sunset of the day of `date`, in its
+// location.
+func SunDayAt(date time.Time, c Coordinates) SunDay {
+	midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	end := midnight.AddDate(0, 0, 1)
+	day := SunDay{Up: sunUp(midnight.Add(12*time.Hour), c)}
+
+	up := sunUp(midnight, c)
+	for t := midnight; t.Before(end); t = t.Add(sunSearchStep) {
+		next := t.Add(sunSearchStep)
+		if next.After(end) {
+			next = end
+		}
+		if sunUp(next, c) == up {
+			continue
+		}
+
+		// Narrow the change down to the second
+		before, after := t, next
+		for after.Sub(before) > time.Second {
+			middle := before.Add(after.Sub(before) / 2)
+			if sunUp(middle, c) == up {
+				before = middle
+			} else {
+				after = middle
+			}
+		}
+		up = !up
+		if up && day.Rise.IsZero() {
+			day.Rise = after
+		} else if !up && day.Set.IsZero() {
+			day.Set = after
+		}
+	}
+	return day
+}
+
+// Sunrise and sunset of the clock's day in a zone, as times in the zone,
+// or "" for those that don't happen that day. Without coordinates, ok is
+// false.
+func (m *model) sunTimes(zone *Zone) (day SunDay, rise string, set string, ok bool) {
+	c, ok := zone.coordinates()
+	if !ok {
+		return day, "", "", false
+	}
+	day = SunDayAt(zone.currentTime(m.clock.t), c)
+
+	layout := "3:04PM"
+	if m.zoneMilitary(zone) {
+		layout = "15:04"
+	}
+	format := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return zone.currentTime(t).Format(layout)
+	}
+	return day, format(day.Rise), format(day.Set), true
+}
+
+// Note of the sunrise and sunset of the clock's day in a zone, like
+// "☀ 07:21-17:25", or "" without coordinates.
+func (m *model) sunNote(zone *Zone) string {
+	day, rise, set, ok := m.sunTimes(zone)
+	switch {
+	case !ok:
+		return ""
+	case rise == "" && set == "" && day.Up:
+		return "☀ 24h"
+	case rise == "" && set == "":
+		return "☀ 0h"
+	case rise == "":
+		rise = "--"
+	case set == "":
+		set = "--"
+	}
+	return fmt.Sprintf("☀ %s-%s", rise, set)
+}
+
+// Color of a timeline cell of a zone, starting at `t` and lasting `step`:
+// from the daylight when shown, or else from the bands.
+func (m *model) cellColor(zone *Zone, t time.Time, step time.Duration) string {
+	if m.showSun {
+		if c, ok := zone.coordinates(); ok {
+			switch up, upAfter := sunUp(t, c), sunUp(t.Add(step), c); {
+			case up && upAfter:
+				return palette().Day
+			case upAfter:
+				return palette().Morning
+			case up:
+				return palette().Evening
+			}
+			return palette().Night
+		}
+	}
+	return m.hourColor(zone, t.Hour())
+}
+
+// Symbol marking a timeline cell in plain text: * in daylight, / and \
+// for sunrise and sunset, when daylight is shown, or else that of the
+// band.
+func (m *model) cellSymbol(zone *Zone, t time.Time, step time.Duration) string {
+	if m.showSun && term == termenv.Ascii {
+		if c, ok := zone.coordinates(); ok {
+			switch up, upAfter := sunUp(t, c), sunUp(t.Add(step), c); {
+			case up && upAfter:
+				return "*"
+			case upAfter:
+				return "/"
+			case up:
+				return "\\"
+			}
+			return " "
+		}
+	}
+	return m.hourSymbol(zone, t.Hour())
+}
@@ -0,0 +1,178 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseISO6709(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Coordinates
+	}{
+		{"+4852+00220", Coordinates{48 + 52.0/60, 2 + 20.0/60}},
+		{"+415100-0873900", Coordinates{41 + 51.0/60, -(87 + 39.0/60)}},
+		{"-3352+15113", Coordinates{-(33 + 52.0/60), 151 + 13.0/60}},
+		{"4852+00220", Coordinates{}},
+	}
+	for _, test := range tests {
+		got, err := parseISO6709(test.input)
+		if test.want == (Coordinates{}) {
+			if err == nil {
+				t.Errorf("Expected an error for %q, but got %v", test.input, got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("parseISO6709(%q) = %v, %v, want %v", test.input, got, err, test.want)
+		}
+	}
+}
+
+func TestLookupCoordinates(t *testing.T) {
+	kolkata, ok := LookupCoordinates("Asia/Kolkata")
+	if !ok {
+		t.Fatal("Expected coordinates for Asia/Kolkata")
+	}
+	if calcutta, ok := LookupCoordinates("Asia/Calcutta"); !ok || calcutta != kolkata {
+		t.Errorf("Expected the coordinates of Asia/Kolkata for its old name, but got %v", calcutta)
+	}
+	if c, ok := LookupCoordinates("UTC"); ok {
+		t.Errorf("Expected no coordinates for UTC, but got %v", c)
+	}
+}
+
+func TestSunDayAt(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parisCoordinates, _ := LookupCoordinates("Europe/Paris")
+	tromso := Coordinates{Latitude: 69.65, Longitude: 18.96}
+
+	tests := []struct {
+		name        string
+		date        time.Time
+		coordinates Coordinates
+		rise        string
+		set         string
+		up          bool
+	}{
+		{"Summer solstice", time.Date(2024, 6, 21, 12, 0, 0, 0, paris), parisCoordinates, "05:47", "21:58", true},
+		{"Winter solstice", time.Date(2024, 12, 21, 12, 0, 0, 0, paris), parisCoordinates, "08:42", "16:56", true},
+		{"Polar day", time.Date(2024, 6, 21, 12, 0, 0, 0, paris), tromso, "", "", true},
+		{"Polar night", time.Date(2024, 12, 21, 12, 0, 0, 0, paris), tromso, "", "", false},
+	}
+	for _, test := range tests {
+		day := SunDayAt(test.date, test.coordinates)
+		if day.Up != test.up {
+			t.Errorf("%s: expected the sun up at noon: %v", test.name, test.up)
+		}
+		for _, check := range []struct {
+			label string
+			got   time.Time
+			want  string
+		}{{"sunrise", day.Rise, test.rise}, {"sunset", day.Set, test.set}} {
+			if check.want == "" {
+				if !check.got.IsZero() {
+					t.Errorf("%s: expected no %s, but got %v", test.name, check.label, check.got)
+				}
+				continue
+			}
+			want, _ := time.ParseInLocation("2006-01-02 15:04", test.date.Format("2006-01-02 ")+check.want, paris)
+			if difference := check.got.Sub(want).Abs(); difference > 2*time.Minute {
+				t.Errorf("%s: expected the %s at %v, but got %v", test.name, check.label, want, check.got.In(paris))
+			}
+		}
+	}
+}
+
+func TestSunNote(t *testing.T) {
+	zones := LoadDstTestZones(t)
+	m := model{
+		zones:      zones,
+		clock:      *NewClockTime(time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC)),
+		isMilitary: true,
+	}
+	tests := []struct {
+		zone *Zone
+		want string
+	}{
+		{zones[0], ""},
+		{zones[1], "☀ 05:47-21:58"},
+		{&Zone{Loc: time.UTC, Name: "Tromsø", Coordinates: &Coordinates{69.65, 18.96}}, "☀ 24h"},
+		{&Zone{Loc: time.UTC, Name: "McMurdo", Coordinates: &Coordinates{-77.85, 166.67}}, "☀ 0h"},
+	}
+	for _, test := range tests {
+		if got := m.sunNote(test.zone); got != test.want {
+			t.Errorf("Sun note of %s: expected %q, but got %q", test.zone.Name, test.want, got)
+		}
+	}
+}
+
+func TestDescribeSun(t *testing.T) {
+	zones := LoadDstTestZones(t)
+	m := model{
+		zones:      zones,
+		clock:      *NewClockTime(time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC)),
+		isMilitary: true,
+	}
+	tests := []struct {
+		zone *Zone
+		want string
+	}{
+		{zones[0], ""},
+		{zones[1], "sunrise 05:47, sunset 21:58"},
+		{&Zone{Loc: time.UTC, Name: "Tromsø", Coordinates: &Coordinates{69.65, 18.96}}, "sun up all day"},
+		{&Zone{Loc: time.UTC, Name: "McMurdo", Coordinates: &Coordinates{-77.85, 166.67}}, "sun down all day"},
+	}
+	for _, test := range tests {
+		if got := m.describeSun(test.zone); got != test.want {
+			t.Errorf("Sun of %s: expected %q, but got %q", test.zone.Name, test.want, got)
+		}
+	}
+
+	m.showSun = true
+	if got, want := m.describeZone(zones[1]), "Europe/Paris, 14:00 Friday, working hours, sunrise 05:47, sunset 21:58, "; !strings.HasPrefix(got, want) {
+		t.Errorf("Expected the description of Paris to start with %q, but got %q", want, got)
+	}
+}
+
+func TestReadZoneCoordinates(t *testing.T) {
+	latitude, longitude, invalid := 12.97, 77.59, 200.0
+	valid := []ConfigFileZone{
+		{ID: "Asia/Kolkata"},
+		{ID: "Asia/Kolkata", Latitude: &latitude, Longitude: &longitude},
+	}
+	for _, zoneConf := range valid {
+		if _, err := ReadZonesFromFile(time.Now(), zoneConf); err != nil {
+			t.Errorf("Expected zone %v to be valid: %v", zoneConf, err)
+		}
+	}
+	invalidZones := []ConfigFileZone{
+		{ID: "Asia/Kolkata", Latitude: &latitude},
+		{ID: "Asia/Kolkata", Latitude: &latitude, Longitude: &invalid},
+	}
+	for _, zoneConf := range invalidZones {
+		if _, err := ReadZonesFromFile(time.Now(), zoneConf); err == nil {
+			t.Errorf("Expected zone %v to be invalid", zoneConf)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Palette holds the colors of the UI on one kind of terminal background.
+// Colors are "#rrggbb" hex codes, or ANSI color numbers from 0 to 255.
+type Palette struct {
+	Morning    string // Time of day bands of the timelines
+	Day        string
+	Evening    string
+	Night      string
+	CursorText string // Text of the cursor, over the color of its hour
+	Highlight  string // Marker of the highlighted zone, reversed when empty
+	Dates      string // Date changes under the timelines
+	DateTime   string // Dates and times of the zone headers
+	Text       string // Zone names, and other text
+	Status     string // Status bar
+}
+
+// Theme has a palette for dark, and one for light terminal backgrounds.
+type Theme struct {
+	Dark  Palette
+	Light Palette
+}
+
+// Built-in themes, selected by name in the config file.
+var Themes = map[string]Theme{
+	"default": {
+		Dark: Palette{
+			Morning:    "#98E1D8",
+			Day:        "#E8C64D",
+			Evening:    "#C95F48",
+			Night:      "#5957C9",
+			CursorText: "#262626",
+			Dates:      "#7B7573",
+			DateTime:   "#757575",
+			Text:       "#ECEAD9",
+			Status:     "#605C5A",
+		},
+		Light: Palette{
+			Morning:    "#35B6A6",
+			Day:        "#FA8F2D",
+			Evening:    "#FC6442",
+			Night:      "#664FC3",
+			CursorText: "#f1f1f1",
+			Dates:      "#777266",
+			DateTime:   "#777266",
+			Text:       "#32312B",
+			Status:     "#939183",
+		},
+	},
+	// Okabe & Ito's palette, distinguishable with the common kinds of
+	// color blindness.
+	"okabe-ito": {
+		Dark: Palette{
+			Morning:    "#56B4E9",
+			Day:        "#F0E442",
+			Evening:    "#E69F00",
+			Night:      "#0072B2",
+			CursorText: "#000000",
+			Highlight:  "#CC79A7",
+			Dates:      "#999999",
+			DateTime:   "#999999",
+			Text:       "#FFFFFF",
+			Status:     "#777777",
+		},
+		Light: Palette{
+			Morning:    "#009E73",
+			Day:        "#E69F00",
+			Evening:    "#D55E00",
+			Night:      "#0072B2",
+			CursorText: "#FFFFFF",
+			Highlight:  "#CC79A7",
+			Dates:      "#666666",
+			DateTime:   "#666666",
+			Text:       "#000000",
+			Status:     "#888888",
+		},
+	},
+	// IBM's color blind safe palette.
+	"ibm": {
+		Dark: Palette{
+			Morning:    "#648FFF",
+			Day:        "#FFB000",
+			Evening:    "#FE6100",
+			Night:      "#785EF0",
+			CursorText: "#000000",
+			Highlight:  "#DC267F",
+			Dates:      "#A0A0A0",
+			DateTime:   "#A0A0A0",
+			Text:       "#F4F4F4",
+			Status:     "#6F6F6F",
+		},
+		Light: Palette{
+			Morning:    "#648FFF",
+			Day:        "#FFB000",
+			Evening:    "#FE6100",
+			Night:      "#785EF0",
+			CursorText: "#000000",
+			Highlight:  "#DC267F",
+			Dates:      "#6F6F6F",
+			DateTime:   "#6F6F6F",
+			Text:       "#161616",
+			Status:     "#8D8D8D",
+		},
+	},
+	// Shades of gray, for terminals with few colors, or readers who
+	// prefer none.
+	"mono": {
+		Dark: Palette{
+			Morning:    "250",
+			Day:        "255",
+			Evening:    "247",
+			Night:      "242",
+			CursorText: "232",
+			Dates:      "244",
+			DateTime:   "244",
+			Text:       "255",
+			Status:     "240",
+		},
+		Light: Palette{
+			Morning:    "240",
+			Day:        "232",
+			Evening:    "243",
+			Night:      "247",
+			CursorText: "255",
+			Dates:      "244",
+			DateTime:   "244",
+			Text:       "232",
+			Status:     "247",
+		},
+	},
+}
+
+// The theme in use.
+var theme = Themes["default"]
+
+// The palette of the theme in use, for the terminal's background.
+func palette() Palette {
+	if hasDarkBackground {
+		return theme.Dark
+	}
+	return theme.Light
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// CheckColor validates a color: a "#rgb" or "#rrggbb" hex code, or an ANSI
+// color number.
+func CheckColor(color string) error {
+	if hexColor.MatchString(color) {
+		return nil
+	}
+	if n, err := strconv.Atoi(color); err == nil && n >= 0 && n <= 255 {
+		return nil
+	}
+	return fmt.Errorf("invalid color %q: use a #rrggbb hex code, or an ANSI color number", color)
+}
+
+// LoadTheme returns the built-in theme `name`, or the default one when
+// the name is empty.
+func LoadTheme(name string) (*Theme, error) {
+	if name == "" {
+		name = "default"
+	}
+	t, ok := Themes[strings.ToLower(name)]
+	if !ok {
+		var names []string
+		for themeName := range Themes {
+			names = append(names, themeName)
+		}
+		slices.Sort(names)
+		return nil, fmt.Errorf("unknown theme %s, use one of: %s", name, strings.Join(names, ", "))
+	}
+	return &t, nil
+}
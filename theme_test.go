@@ -0,0 +1,100 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"testing"
+)
+
+func TestCheckColor(t *testing.T) {
+	tests := []struct {
+		color string
+		ok    bool
+	}{
+		{"#98E1D8", true},
+		{"#fff", true},
+		{"0", true},
+		{"255", true},
+		{"256", false},
+		{"-1", false},
+		{"#98E1D", false},
+		{"98E1D8", false},
+		{"red", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		err := CheckColor(test.color)
+		if test.ok != (err == nil) {
+			t.Errorf("Expected color %q to be valid: %v, but got: %v", test.color, test.ok, err)
+		}
+	}
+}
+
+func TestBuiltinThemes(t *testing.T) {
+	for name, theme := range Themes {
+		for _, palette := range []Palette{theme.Dark, theme.Light} {
+			colors := []string{
+				palette.Morning,
+				palette.Day,
+				palette.Evening,
+				palette.Night,
+				palette.CursorText,
+				palette.Dates,
+				palette.DateTime,
+				palette.Text,
+				palette.Status,
+			}
+			if palette.Highlight != "" {
+				colors = append(colors, palette.Highlight)
+			}
+			for _, color := range colors {
+				if err := CheckColor(color); err != nil {
+					t.Errorf("Theme %s: %v", name, err)
+				}
+			}
+		}
+	}
+}
+
+func TestReadThemeFromFile(t *testing.T) {
+	theme, err := ReadThemeFromFile(ConfigFileTheme{
+		Name: "IBM",
+		Dark: ConfigFilePalette{Day: "#123456", Status: "244"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Dark.Day != "#123456" || theme.Dark.Status != "244" {
+		t.Errorf("Expected overridden dark colors, but got %+v", theme.Dark)
+	}
+	if theme.Dark.Night != Themes["ibm"].Dark.Night {
+		t.Errorf("Expected the ibm theme's night color, but got %v", theme.Dark.Night)
+	}
+	if theme.Light != Themes["ibm"].Light {
+		t.Errorf("Expected the ibm theme's light palette, but got %+v", theme.Light)
+	}
+	if Themes["ibm"].Dark.Day == "#123456" {
+		t.Errorf("Overriding colors changed the built-in theme")
+	}
+
+	if _, err := ReadThemeFromFile(ConfigFileTheme{Name: "solarized"}); err == nil {
+		t.Errorf("Expected an error for an unknown theme")
+	}
+	if _, err := ReadThemeFromFile(ConfigFileTheme{Light: ConfigFilePalette{Text: "#ECEAD"}}); err == nil {
+		t.Errorf("Expected an error for an invalid color")
+	}
+}
@@ -23,10 +23,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mattn/go-runewidth"
 	"github.com/muesli/termenv"
 	xterm "golang.org/x/term"
 )
 
+// FormatStyle is the index of the format of dates and times, among the
+// configured ones, or else DefaultFormats.
 type FormatStyle int
 
 const (
@@ -35,26 +38,13 @@ const (
 	UnixFormatStyle
 )
 
-func (fs FormatStyle) next() FormatStyle {
-	switch (fs) {
-	case DefaultFormatStyle:
-		return IsoFormatStyle
-	case IsoFormatStyle:
-		return UnixFormatStyle
-	default:
-		return DefaultFormatStyle
-	}
+// Cycle through `count` format styles.
+func (fs FormatStyle) next(count int) FormatStyle {
+	return (fs + 1) % FormatStyle(count)
 }
 
-func (fs FormatStyle) previous() FormatStyle {
-	switch (fs) {
-	case DefaultFormatStyle:
-		return UnixFormatStyle
-	case UnixFormatStyle:
-		return IsoFormatStyle
-	default:
-		return DefaultFormatStyle
-	}
+func (fs FormatStyle) previous(count int) FormatStyle {
+	return (fs - 1 + FormatStyle(count)) % FormatStyle(count)
 }
 
 type ZoneStyle int
@@ -87,182 +77,508 @@ func (zs ZoneStyle) previous() ZoneStyle {
 	}
 }
 
+// GridResolution is the time span of a column of the timelines.
+type GridResolution int
+
+const (
+	HourGrid GridResolution = iota
+	HalfHourGrid
+	QuarterHourGrid
+)
+
+func (g GridResolution) next() GridResolution {
+	switch (g) {
+	case HourGrid:
+		return HalfHourGrid
+	case HalfHourGrid:
+		return QuarterHourGrid
+	default:
+		return HourGrid
+	}
+}
+
+// Duration of a column.
+func (g GridResolution) Duration() time.Duration {
+	switch (g) {
+	case HalfHourGrid:
+		return 30 * time.Minute
+	case QuarterHourGrid:
+		return 15 * time.Minute
+	default:
+		return time.Hour
+	}
+}
+
+// Width of a column of the timelines
+const ColumnWidth = 4
+
 // Width required to display 24 hours
 const UIWidth = 94
 const MinimumZoneHeaderPadding = 6
 const MaximumZoneHeaderColumns = UIWidth + MinimumZoneHeaderPadding
 
-func (m model) View() string {
-	s := normalTextStyle("\n  What time is it?\n\n").String()
-
-	zoneHeaderWidth := MaximumZoneHeaderColumns
+// Width available to the UI: the terminal's, as last reported by
+// bubbletea, or else from $COLUMNS or the terminal itself, up to what the
+// full grid needs.
+func (m model) viewWidth() int {
+	if m.termWidth > 0 {
+		return min(m.termWidth, MaximumZoneHeaderColumns)
+	}
 	envWidth, envErr := strconv.Atoi(os.Getenv("COLUMNS"))
 	if envErr == nil {
-		zoneHeaderWidth = min(envWidth, zoneHeaderWidth)
+		return min(envWidth, MaximumZoneHeaderColumns)
+	}
+	fd := int(os.Stdout.Fd())
+	if xterm.IsTerminal(fd) {
+		termWidth, _, termErr := xterm.GetSize(fd)
+		if termErr == nil {
+			return min(termWidth, MaximumZoneHeaderColumns)
+		}
+	}
+	return MaximumZoneHeaderColumns
+}
+
+func (m model) View() string {
+	if m.showBigClock {
+		return m.bigClockView() + bell(&m)
+	}
+
+	s := header(&m)
+
+	zoneHeaderWidth := m.viewWidth()
+	if m.accessible {
+		s += accessibleZones(&m)
 	} else {
-		fd := int(os.Stdout.Fd())
-		if xterm.IsTerminal(fd) {
-			termWidth, _, termErr := xterm.GetSize(fd)
-			if termErr == nil {
-				zoneHeaderWidth = min(termWidth, zoneHeaderWidth)
-			}
+		switch m.currentLayout(zoneHeaderWidth) {
+		case CompactLayout:
+			s += compactZones(&m, zoneHeaderWidth)
+		case VerticalLayout:
+			s += verticalZones(&m)
+		case WeekLayout:
+			s += weekZones(&m, zoneHeaderWidth)
+		case MapLayout:
+			s += mapZones(&m, zoneHeaderWidth)
+		default:
+			s += gridZones(&m, zoneHeaderWidth)
 		}
 	}
 
+	if len(m.deadlines) > 0 {
+		s += "\n"
+		for _, deadline := range m.deadlines {
+			s += fmt.Sprintf("  %s\n", formatDeadline(&m, deadline))
+		}
+	}
+
+	if m.showDST {
+		s += "\n" + dstPanel(&m)
+	}
+
+	if m.showBookmarks {
+		s += "\n" + bookmarksPanel(&m)
+	}
+
+	if m.showCalendar {
+		s += "\n" + calendarPanel(&m)
+	}
+
+	if m.interactive {
+		s += status(m)
+	}
+	return s + bell(&m)
+}
+
+// The terminal bell, in the view rendered after an alarm rang: bubbletea owns
+// the output, and the bell rings as the renderer writes it.
+func bell(m *model) string {
+	if m.bell {
+		return "\a"
+	}
+	return ""
+}
+
+// The part of the day shown in the timelines of every zone: columns of
+// `step` starting at `midnight`, of which those from `first` to `last`
+// (excluded) are visible.
+type timelines struct {
+	midnight  time.Time
+	step      time.Duration
+	cursor    int
+	first     int
+	last      int
+	deadlines map[int]bool
+}
+
+// A column of the timeline of a zone.
+type timelineCell struct {
+	time      time.Time // In the zone
+	column    int
+	dayChange bool   // The date changed since the previous column
+	dstChange string // "=DST" when DST starts, "≠DST" when it ends
+}
+
+// Compute the timelines, scrolled to keep the cursor in view when there
+// is room for fewer than all columns.
+func (m *model) timelines(room int) timelines {
+	// Hour columns start at the minute of the clock, and finer columns at
+	// midnight, so that they fall on half or quarter hours.
+	columnMinute := m.clock.t.Minute()
+	if m.grid != HourGrid {
+		columnMinute = 0
+	}
 	midnight := time.Date(
 		m.clock.t.Year(),
 		m.clock.t.Month(),
 		m.clock.t.Day(),
 		0, // Hours
-		m.clock.t.Minute(),
+		columnMinute,
 		0, // Seconds
 		0, // Nanoseconds
 		m.clock.t.Location(),
 	)
-	midnightOffset := time.Duration(m.clock.t.UnixNano() - midnight.UnixNano())
-	cursorColumn := int(midnightOffset / time.Hour)
+	step := m.grid.Duration()
+	cursor := int(m.clock.t.Sub(midnight) / step)
+	first, last := visibleColumns(cursor, int(24 * time.Hour / step), room)
+	return timelines{
+		midnight:  midnight,
+		step:      step,
+		cursor:    cursor,
+		first:     first,
+		last:      last,
+		deadlines: m.deadlineColumns(midnight, step),
+	}
+}
+
+// The visible columns of the timeline of a zone.
+func (tl timelines) cells(zone *Zone) []timelineCell {
+	var cells []timelineCell
+	previous := zone.currentTime(tl.midnight.Add(time.Duration(tl.first - 1) * tl.step))
+	for column := tl.first; column < tl.last; column++ {
+		t := zone.currentTime(tl.midnight.Add(time.Duration(column) * tl.step))
+		cell := timelineCell{
+			time:      t,
+			column:    column,
+			dayChange: t.Hour() < previous.Hour(),
+		}
+		if t.IsDST() != previous.IsDST() {
+			if t.IsDST() {
+				cell.dstChange = "=DST"
+			} else {
+				cell.dstChange = "≠DST"
+			}
+		}
+		cells = append(cells, cell)
+		previous = t
+	}
+	return cells
+}
+
+// Style the label of a timeline column with the color of its hour, and
+// highlight the cursor.
+func timelineCellStyle(label string, color string, cursor bool) string {
+	out := termenv.String(label).Foreground(term.Color(color))
+	if cursor {
+		out = out.Background(term.Color(color))
+		out = out.Foreground(term.Color(palette().CursorText))
+		if hasDarkBackground {
+			out = out.Bold()
+		}
+	}
+	return out.String()
+}
+
+// Render the zones as rows of headers and timelines.
+func gridZones(m *model, zoneHeaderWidth int) string {
+	s := ""
+	tl := m.timelines((zoneHeaderWidth - 2) / ColumnWidth)
 
 	// Show hours for each zone
 	for i, zone := range m.zones {
 		hours := strings.Builder{}
 		dates := strings.Builder{}
-		timeInZone := zone.currentTime(m.clock.t)
-		midnightInZone := timeInZone.Add(-midnightOffset)
-		wasDST := midnightInZone.Add(-time.Hour).IsDST()
-		previousHour := midnightInZone.Add(-time.Hour).Hour()
-		highlighted := i == (m.highlighted - 1)
+		cells := tl.cells(zone)
 
 		dateChanged := false
-		for column := 0; column < 24; column++ {
-			time := midnightInZone.Add(time.Duration(column) * time.Hour)
-			nowDST := time.IsDST()
-			hour := time.Hour()
-			out := termenv.String(fmt.Sprintf("%2d", hour))
-
-			out = out.Foreground(term.Color(hourColorCode(hour)))
-			// Cursor
-			if column == cursorColumn {
-				out = out.Background(term.Color(hourColorCode(hour)))
-				if hasDarkBackground {
-					out = out.Foreground(term.Color("#262626")).Bold()
-				} else {
-					out = out.Foreground(term.Color("#f1f1f1"))
-				}
+		for _, cell := range cells {
+			hours.WriteString(timelineCellStyle(formatColumn(cell.time, tl.step), m.cellColor(zone, cell.time, tl.step), cell.column == tl.cursor))
+			if tl.deadlines[cell.column] {
+				hours.WriteString(DeadlineMarker)
+			} else {
+				hours.WriteString(m.cellSymbol(zone, cell.time, tl.step) + " ")
 			}
-			hours.WriteString(out.String())
-			hours.WriteString("  ")
 
 			// Show the day under the hour, when the date changes.
 			if m.showDates {
-				if hour < previousHour {
-					dates.WriteString(formatDayChange(&m, zone))
+				if cell.dayChange {
+					dates.WriteString(formatDayChange(m, zone))
 					dateChanged = true
 				}
 
-				if wasDST != nowDST {
-					if nowDST {
-						dates.WriteString("=DST")
-					} else {
-						dates.WriteString("≠DST")
-					}
+				if cell.dstChange != "" {
+					dates.WriteString(cell.dstChange)
 				} else if !dateChanged {
 					dates.WriteString("    ")
 				}
 			}
+		}
 
-			wasDST = nowDST
-			previousHour = hour
+		zoneHeader := gridZoneHeader(m, zone, zoneHeaderWidth, cells[0].time)
+		marker := m.rowMarker(i)
+		lines := append(zoneHeader, hours.String(), dates.String())
+		for _, line := range lines {
+			s += fmt.Sprintf("%s%s\n", marker, line)
 		}
+	}
+	return s
+}
 
-		var datetime string
-		switch m.formatStyle {
-		case IsoFormatStyle:
-				datetime = timeInZone.Format("2006-01-02T15:04-07:00")
-		case UnixFormatStyle:
-			_, weekOfYear := timeInZone.ISOWeek()
-			dayOfYear := timeInZone.Format("__2")
-			yesNo := map[bool]string{true: "With", false: "No"}
-			datetime = fmt.Sprintf(
-				"%v DST, Week %v, Day %v, Unix %v",
-				yesNo[timeInZone.IsDST()],
-				weekOfYear,
-				dayOfYear,
-				timeInZone.Unix(),
-			)
-		default:
-			if m.isMilitary {
-				datetime = zone.ShortMT(m.clock.t)
-			} else {
-				datetime = zone.ShortDT(m.clock.t)
-			}
+// Lines of the header of a zone in the grid: its name, and its date and
+// time, right-aligned, or stacked under the name when they don't fit.
+func gridZoneHeader(m *model, zone *Zone, zoneHeaderWidth int, firstColumn time.Time) []string {
+	timeInZone := zone.currentTime(m.clock.t)
+	datetime := m.formatZoneTime(zone, timeInZone)
+	zoneString := m.formatZoneName(zone, timeInZone, fractionalOffsetNote(firstColumn, m.grid == HourGrid))
+	if m.showSun {
+		if note := m.sunNote(zone); note != "" {
+			zoneString += "  " + note
 		}
+	}
+	clockString := zone.ClockEmoji(m.clock.t)
 
-		var zoneString = zone.VerboseString(timeInZone)
-		switch m.zoneStyle {
-		case WithZOffsetZoneStyle:
-			utcOffset := timeInZone.Format("Z-07:00")
-			zoneString = fmt.Sprintf("[%s] %s", utcOffset, zoneString)
-		case WithRelativeZoneStyle:
-			_, otherOffset := timeInZone.Zone()
-			_, localOffset := m.clock.t.Zone()
-			relativeOffset := m.clock.t.In(time.FixedZone("", otherOffset - localOffset)).Format("-07:00")
-			zoneString = fmt.Sprintf("[%s] %s", relativeOffset, zoneString)
-		default:
+	usedZoneHeaderWidth := termenv.String(clockString + zoneString + datetime).Width()
+	if usedZoneHeaderWidth + 4 > zoneHeaderWidth {
+		return []string{
+			fmt.Sprintf("%s %s", clockString, normalTextStyle(zoneString)),
+			fmt.Sprintf("   %s", dateTimeStyle(datetime)),
 		}
+	}
+	unusedZoneHeaderWidth := max(0, zoneHeaderWidth - usedZoneHeaderWidth - MinimumZoneHeaderPadding)
+	rightAlignmentSpace := strings.Repeat(" ", unusedZoneHeaderWidth)
+	return []string{
+		fmt.Sprintf("%s %s %s%s", clockString, normalTextStyle(zoneString), rightAlignmentSpace, dateTimeStyle(datetime)),
+	}
+}
 
-		clockString := zone.ClockEmoji(m.clock.t)
-		usedZoneHeaderWidth := termenv.String(clockString + zoneString + datetime).Width()
-		unusedZoneHeaderWidth := max(0, zoneHeaderWidth - usedZoneHeaderWidth - MinimumZoneHeaderPadding)
-		rightAlignmentSpace := strings.Repeat(" ", unusedZoneHeaderWidth)
-		zoneHeader := fmt.Sprintf("%s %s %s%s", clockString, normalTextStyle(zoneString), rightAlignmentSpace, dateTimeStyle(datetime))
+// Whether to show 24-hour time in a zone: as toggled for the zone, as set
+// in its config, or else for all zones.
+func (m *model) zoneMilitary(zone *Zone) bool {
+	if military, ok := m.militaryZones[zone]; ok {
+		return military
+	}
+	if zone.Military != nil {
+		return *zone.Military
+	}
+	return m.isMilitary
+}
 
-		marker := "  "
-		if highlighted {
-			marker = termenv.String(">>").Reverse().String()
+// Switch between 12 and 24-hour time in the highlighted zone, or in all
+// zones without their own setting when none is highlighted. Zones are
+// shared with the config, so the model keeps what was toggled in them.
+func (m *model) toggleMilitary() {
+	if m.highlighted > 0 && m.highlighted <= len(m.zones) {
+		zone := m.zones[m.highlighted - 1]
+		if m.militaryZones == nil {
+			m.militaryZones = make(map[*Zone]bool)
 		}
-		lines := []string{zoneHeader, hours.String(), dates.String()}
-		for _, line := range lines {
-			s += fmt.Sprintf("%s%s\n", marker, line)
+		m.militaryZones[zone] = !m.zoneMilitary(zone)
+		return
+	}
+	m.isMilitary = !m.isMilitary
+}
+
+// Format the date and time in a zone, in the current format style.
+func (m *model) formatZoneTime(zone *Zone, timeInZone time.Time) string {
+	format := m.currentFormat()
+	if format.Layout != "" {
+		return format.Format(timeInZone)
+	}
+
+	var datetime string
+	switch format.Name {
+	case "iso":
+		if m.showSeconds {
+			datetime = timeInZone.Format("2006-01-02T15:04:05-07:00")
+		} else {
+			datetime = timeInZone.Format("2006-01-02T15:04-07:00")
+		}
+	case "unix":
+		_, weekOfYear := timeInZone.ISOWeek()
+		dayOfYear := timeInZone.Format("__2")
+		yesNo := map[bool]string{true: "With", false: "No"}
+		datetime = fmt.Sprintf(
+			"%v DST, Week %v, Day %v, Unix %v",
+			yesNo[timeInZone.IsDST()],
+			weekOfYear,
+			dayOfYear,
+			timeInZone.Unix(),
+		)
+	default:
+		switch {
+		case m.zoneMilitary(zone) && m.showSeconds:
+			datetime = zone.ShortMTSeconds(m.clock.t)
+		case m.zoneMilitary(zone):
+			datetime = zone.ShortMT(m.clock.t)
+		case m.showSeconds:
+			datetime = zone.ShortDTSeconds(m.clock.t)
+		default:
+			datetime = zone.ShortDT(m.clock.t)
 		}
 	}
+	return datetime
+}
 
-	if m.interactive {
-		s += status(m)
+// Format the name of a zone, with an optional note, in the current zone
+// style.
+func (m *model) formatZoneName(zone *Zone, timeInZone time.Time, note string) string {
+	var zoneString = zone.VerboseString(timeInZone)
+	if note != "" {
+		zoneString = fmt.Sprintf("%s (%s)", zoneString, note)
 	}
-	return s
+	switch m.zoneStyle {
+	case WithZOffsetZoneStyle:
+		utcOffset := timeInZone.Format("Z-07:00")
+		zoneString = fmt.Sprintf("[%s] %s", utcOffset, zoneString)
+	case WithRelativeZoneStyle:
+		_, otherOffset := timeInZone.Zone()
+		_, localOffset := m.clock.t.Zone()
+		relativeOffset := m.clock.t.In(time.FixedZone("", otherOffset - localOffset)).Format("-07:00")
+		zoneString = fmt.Sprintf("[%s] %s", relativeOffset, zoneString)
+	default:
+	}
+	return zoneString
 }
 
-// Generate the help lines
-func generateKeymapStrings(k Keymaps, showAll bool) []string {
-	helpKey := fmt.Sprintf("%s: help", k.Help[0])
-	quitKey := fmt.Sprintf("%s: quit", k.Quit[0])
+// Marker in front of the lines of a zone: highlighted, or ringing.
+func (m *model) rowMarker(row int) string {
+	marker := "  "
+	if row == m.highlighted - 1 {
+		marker = highlightStyle(">>").String()
+	}
+	if m.ringing[row] {
+		marker = termenv.String("🔔").Blink().String()
+	}
+	return marker
+}
 
-	if showAll {
-		delimiter := ", "
-		return []string {
-			strings.Join(
-				[]string {
-					helpKey,
-					fmt.Sprintf("%s/%s/%s: minutes", k.PrevMinute[0], k.NextMinute[0], k.ZeroMinute[0]),
-					fmt.Sprintf("%s/%s: hours", k.PrevHour[0], k.NextHour[0]),
-					fmt.Sprintf("%s/%s: days", k.PrevDay[0], k.NextDay[0]),
-					fmt.Sprintf("%s/%s: weeks", k.PrevWeek[0], k.NextWeek[0]),
-					fmt.Sprintf("%s: go to now", k.Now[0]),
-					fmt.Sprintf("%s/%s: highlight", k.NextLine[0], k.PrevLine[0]),
-				},
-				delimiter,
-			),
-			strings.Join(
-				[]string {
-					quitKey,
-					fmt.Sprintf("%s: toggle dates", k.ToggleDate[0]),
-					fmt.Sprintf("%s: toggle formats", k.NextFStyle[0]),
-					fmt.Sprintf("%s: toggle zone offsets", k.NextZStyle[0]),
-					fmt.Sprintf("%s: open in web", k.OpenWeb[0]),
-				},
-				delimiter,
-			),
+// Marker shown after the hour of a deadline, in every zone.
+const DeadlineMarker = "⏳"
+
+// Columns of the 24-hour timelines starting at `midnight` that hold a
+// deadline.
+func (m model) deadlineColumns(midnight time.Time, columnDuration time.Duration) map[int]bool {
+	columns := make(map[int]bool)
+	for _, deadline := range m.deadlines {
+		offset := deadline.Time.Sub(midnight)
+		if offset >= 0 && offset < 24*time.Hour {
+			columns[int(offset/columnDuration)] = true
 		}
+	}
+	return columns
+}
+
+// Range of at most `width` columns, out of `count`, centered on the
+// cursor column when possible.
+func visibleColumns(cursorColumn int, count int, width int) (first int, last int) {
+	width = max(1, min(width, count))
+	first = max(0, min(cursorColumn - width / 2, count - width))
+	return first, first + width
+}
+
+// Label a column with its hour when it starts the hour, or with the
+// fraction of the hour it starts at, e.g. " ½" for half past.
+func formatColumn(t time.Time, columnDuration time.Duration) string {
+	if time.Duration(t.Minute()) * time.Minute < columnDuration {
+		return fmt.Sprintf("%2d", t.Hour())
+	}
+	switch t.Minute() {
+	case 15:
+		return " ¼"
+	case 30:
+		return " ½"
+	case 45:
+		return " ¾"
+	default:
+		return fmt.Sprintf("%02d", t.Minute())
+	}
+}
+
+// Note the local minutes of zones whose UTC offset is not a whole number
+// of hours, where hour columns would otherwise look like whole hours.
+func fractionalOffsetNote(t time.Time, showMinutes bool) string {
+	_, offset := t.Zone()
+	if offset % 3600 == 0 {
+		return ""
+	}
+	note := "UTC" + formatOffset(offset)
+	if showMinutes {
+		note = fmt.Sprintf("%s, hours at :%02d", note, t.Minute())
+	}
+	return note
+}
+
+// Describe a deadline: time left, and when it falls in its own zone, and
+// in the highlighted zone.
+func formatDeadline(m *model, d *Deadline) string {
+	when := d.Time.Format("Mon Jan 02 15:04 MST")
+	if m.highlighted > 0 {
+		zone := m.zones[m.highlighted-1]
+		when = fmt.Sprintf("%s, %s in %s", when, zone.currentTime(d.Time).Format("Mon Jan 02 15:04"), zone.Name)
+	}
+
+	countdown := d.Countdown(m.clock.t, m.showSeconds)
+	if d.Name != "" {
+		countdown = fmt.Sprintf("%s: %s", d.Name, countdown)
+	}
+	marker := DeadlineMarker
+	if m.accessible {
+		marker = "Deadline:"
+	}
+	return fmt.Sprintf("%s %s %s", marker, normalTextStyle(countdown), dateTimeStyle("("+when+")"))
+}
+
+// Generate the help lines, wrapped to `width`
+func generateKeymapStrings(k Keymaps, showAll bool, width int) []string {
+	helpKey := fmt.Sprintf("%s: %s", k.Help[0], tr("help"))
+	quitKey := fmt.Sprintf("%s: %s", k.Quit[0], tr("quit"))
+
+	if showAll {
+		return wrapKeymapStrings(
+			width - 2,
+			", ",
+			[]string {
+				helpKey,
+				fmt.Sprintf("%s/%s/%s: %s", k.PrevMinute[0], k.NextMinute[0], k.ZeroMinute[0], tr("minutes")),
+				fmt.Sprintf("%s/%s: %s", k.SnapQuarter[0], k.SnapHalf[0], tr("nearest quarter/half hour")),
+				fmt.Sprintf("%s/%s: %s", k.PrevHour[0], k.NextHour[0], tr("hours")),
+				fmt.Sprintf("%s/%s: %s", k.PrevDay[0], k.NextDay[0], tr("days")),
+				fmt.Sprintf("%s/%s: %s", k.PrevWeek[0], k.NextWeek[0], tr("weeks")),
+				fmt.Sprintf("%s/%s: %s", k.PrevMonth[0], k.NextMonth[0], tr("months")),
+				fmt.Sprintf("%s/%s: %s", k.PrevYear[0], k.NextYear[0], tr("years")),
+				fmt.Sprintf("%s/%s: %s", k.StartOfDay[0], k.StartOfWeek[0], tr("start of day/week")),
+				fmt.Sprintf("%s: %s", k.NextWeekday[0], tr("next weekday")),
+				fmt.Sprintf("%s: %s", k.FirstWeekday[0], tr("first weekday of month")),
+				fmt.Sprintf("%s: %s", k.Now[0], tr("go to now")),
+				fmt.Sprintf("%s/%s: %s", k.Undo[0], k.Redo[0], tr("undo/redo")),
+				fmt.Sprintf("%s/%s: %s", k.NextLine[0], k.PrevLine[0], tr("highlight")),
+				fmt.Sprintf("%s/%s: %s", k.PrevTransition[0], k.NextTransition[0], tr("DST changes")),
+			},
+			[]string {
+				quitKey,
+				fmt.Sprintf("%s: %s", k.ToggleDate[0], tr("toggle dates")),
+				fmt.Sprintf("%s: %s", k.ToggleGrid[0], tr("toggle half/quarter hours")),
+				fmt.Sprintf("%s: %s", k.ToggleMilitary[0], tr("toggle 12/24-hour time")),
+				fmt.Sprintf("%s: %s", k.ToggleSun[0], tr("toggle daylight")),
+				fmt.Sprintf("%s: %s", k.ToggleDST[0], tr("toggle DST changes")),
+				fmt.Sprintf("%s: %s", k.NextFStyle[0], tr("toggle formats")),
+				fmt.Sprintf("%s: %s", k.NextZStyle[0], tr("toggle zone offsets")),
+				fmt.Sprintf("%s: %s", k.NextLayout[0], tr("layouts")),
+				fmt.Sprintf("%s: %s", k.OpenWeb[0], tr("open in web")),
+				fmt.Sprintf("%s: %s", k.AddAlarm[0], tr("add alarm")),
+				fmt.Sprintf("%s/%s: %s", k.AddBookmark[0], k.Bookmarks[0], tr("add/list bookmarks")),
+				fmt.Sprintf("%s: %s", k.Calendar[0], tr("calendar")),
+				fmt.Sprintf("%s: %s", k.BigClock[0], tr("big clock")),
+			},
+		)
 	} else {
 		return []string {
 			helpKey,
@@ -271,21 +587,47 @@ func generateKeymapStrings(k Keymaps, showAll bool) []string {
 	}
 }
 
+// Join groups of help strings into lines of at most `width` characters,
+// starting a new line for each group.
+func wrapKeymapStrings(width int, delimiter string, groups ...[]string) []string {
+	var lines []string
+	for _, group := range groups {
+		line := ""
+		for _, item := range group {
+			switch {
+			case line == "":
+				line = item
+			case runewidth.StringWidth(line + delimiter + item) > width:
+				lines = append(lines, line + strings.TrimRight(delimiter, " "))
+				line = item
+			default:
+				line += delimiter + item
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
 func status(m model) string {
-	var text []string = generateKeymapStrings(m.keymaps, m.showHelp)
+	width := min(m.viewWidth(), UIWidth)
+	var text []string = generateKeymapStrings(m.keymaps, m.showHelp, width)
+	if m.prompt != nil {
+		text = []string{m.prompt.String()}
+	}
+	if m.message != "" {
+		text = append([]string{m.message}, text...)
+	}
 
-	backgroundPadding := strings.Repeat(" ", UIWidth)
 	for i, line := range text {
-		text[i] = ("  " + line + backgroundPadding)[:UIWidth]
+		text[i] = fitWidth("  " + line, width)
 	}
 
-	color := "#939183"
-	if hasDarkBackground {
-		color = "#605C5A"
+	status := termenv.String(strings.Join(text, "\n")).Foreground(term.Color(palette().Status))
+	if m.showHelp && m.prompt == nil {
+		return status.String() + "\n" + strings.Join(bandLegend(&m), "\n")
 	}
 
-	status := termenv.String(strings.Join(text, "\n")).Foreground(term.Color(color))
-
 	return status.String()
 }
 
@@ -295,65 +637,23 @@ func formatDayChange(m *model, z *Zone) string {
 		zTime = zTime.AddDate(0, 0, 1)
 	}
 
-	color := "#777266"
-	if hasDarkBackground {
-		color = "#7B7573"
-	}
-
-	str := termenv.String(fmt.Sprintf("📆 %s", zTime.Format("Mon 02")))
-	return str.Foreground(term.Color(color)).String()
-}
-
-// Return a color matching the time of the day at a given hour.
-func hourColorCode(hour int) (color string) {
-	switch hour {
-	// Morning
-	case 7, 8:
-		if hasDarkBackground {
-			color = "#98E1D8"
-		} else {
-			color = "#35B6A6"
-		}
-
-	// Day
-	case 9, 10, 11, 12, 13, 14, 15, 16, 17:
-		if hasDarkBackground {
-			color = "#E8C64D"
-		} else {
-			color = "#FA8F2D"
-		}
-
-	// Evening
-	case 18, 19:
-		if hasDarkBackground {
-			color = "#C95F48"
-		} else {
-			color = "#FC6442"
-		}
-
-	// Night
-	default:
-		if hasDarkBackground {
-			color = "#5957C9"
-		} else {
-			color = "#664FC3"
-		}
-	}
-	return color
+	str := termenv.String(fmt.Sprintf("📆 %s", formatLocal(zTime, "Mon 02")))
+	return str.Foreground(term.Color(palette().Dates)).String()
 }
 
 func dateTimeStyle(str string) termenv.Style {
-	color := "#777266"
-	if hasDarkBackground {
-		color = "#757575"
-	}
-	return termenv.String(str).Foreground(term.Color(color))
+	return termenv.String(str).Foreground(term.Color(palette().DateTime))
 }
 
 func normalTextStyle(str string) termenv.Style {
-	var color = "#32312B"
-	if hasDarkBackground {
-		color = "#ECEAD9"
+	return termenv.String(str).Foreground(term.Color(palette().Text))
+}
+
+// Style the marker of the highlighted zone.
+func highlightStyle(str string) termenv.Style {
+	style := termenv.String(str)
+	if color := palette().Highlight; color != "" {
+		return style.Foreground(term.Color(color)).Reverse()
 	}
-	return termenv.String(str).Foreground(term.Color(color))
+	return style.Reverse()
 }
@@ -648,3 +648,741 @@ func TestZoneStyles(t *testing.T) {
 		}
 	}
 }
+
+func TestSecondsFormats(t *testing.T) {
+	tests := []struct {
+		formatStyle FormatStyle
+		isMilitary  bool
+		expected    string
+	}{
+		{DefaultFormatStyle, true, "00:01:02, Sun Nov 05, 2017"},
+		{DefaultFormatStyle, false, "12:01:02AM, Sun Nov 05, 2017"},
+		{IsoFormatStyle, true, "2017-11-05T00:01:02+00:00"},
+	}
+
+	for _, test := range tests {
+		state := utcMinuteAfterMidnightModel
+		state.showSeconds = true
+		state.isMilitary = test.isMilitary
+		state.formatStyle = test.formatStyle
+		observed := stripAnsiControlSequences(state.View())
+		if !strings.Contains(observed, test.expected) {
+			t.Errorf("Expected “%s”, but got: “%s”", test.expected, observed)
+		}
+	}
+}
+
+func TestDeadlines(t *testing.T) {
+	testDataFile := "testdata/view/test-deadlines.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aoe, err := ParseDeadline("Submission", "2017-11-04 23:59 AoE", utcMinuteAfterMidnightTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	passed, err := ParseDeadline("", "2017-11-04 20:00 Cuba", utcMinuteAfterMidnightTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		highlighted int
+	}{
+		{"No highlight", 0},
+		{"Highlight Israel", 3},
+	}
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		state := utcMinuteAfterMidnightModel
+		state.zones = LoadDstTestZones(t)[:3]
+		state.deadlines = []*Deadline{aoe, passed}
+		state.highlighted = test.highlighted
+		outputData[i] = txtar.File{
+			Name: test.name,
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("Deadlines: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
+
+func TestGridResolutions(t *testing.T) {
+	testDataFile := "testdata/view/test-grid-resolutions.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones := LoadDstTestZones(t)
+	tests := []struct {
+		name     string
+		grid     GridResolution
+		datetime string
+	}{
+		{"Half hours", HalfHourGrid, "2017-11-05T10:40:00Z"},
+		{"Quarter hours", QuarterHourGrid, "2017-11-05T10:40:00Z"},
+		{"Quarter hours, scrolled to the start", QuarterHourGrid, "2017-11-05T00:40:00Z"},
+		{"Quarter hours, scrolled to the end", QuarterHourGrid, "2017-11-05T23:40:00Z"},
+	}
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		clockTime, err := time.Parse(time.RFC3339, test.datetime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := model{
+			zones:      []*Zone{zones[0], zones[3], zones[4]},
+			clock:      *NewClockTime(clockTime),
+			isMilitary: true,
+			showDates:  true,
+			grid:       test.grid,
+		}
+		outputData[i] = txtar.File{
+			Name: fmt.Sprintf("%s (%s)", test.name, test.datetime),
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("Grid resolutions: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
+
+func TestLayouts(t *testing.T) {
+	testDataFile := "testdata/view/test-layouts.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones := LoadDstTestZones(t)
+	tests := []struct {
+		name        string
+		width       int
+		layout      Layout
+		formatStyle FormatStyle
+	}{
+		{"Full grid", 100, GridLayout, DefaultFormatStyle},
+		{"Compact layout", 100, CompactLayout, DefaultFormatStyle},
+		{"Narrow grid", 60, GridLayout, DefaultFormatStyle},
+		{"Narrow grid, stacked headers", 60, GridLayout, UnixFormatStyle},
+		{"Too narrow for the grid", 40, GridLayout, DefaultFormatStyle},
+		{"Vertical layout", 100, VerticalLayout, DefaultFormatStyle},
+	}
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		state := model{
+			zones:       []*Zone{zones[0], zones[3], zones[5]},
+			clock:       *NewClockTime(time.Date(2017, time.November, 5, 10, 40, 0, 0, time.UTC)),
+			isMilitary:  true,
+			showDates:   true,
+			interactive: true,
+			keymaps:     DefaultKeymaps,
+			layout:      test.layout,
+			formatStyle: test.formatStyle,
+			termWidth:   test.width,
+		}
+		outputData[i] = txtar.File{
+			Name: fmt.Sprintf("%s (%d columns)", test.name, test.width),
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("Layouts: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
+
+func TestVerticalLayout(t *testing.T) {
+	testDataFile := "testdata/view/test-vertical-layout.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones := LoadDstTestZones(t)
+	tests := []struct {
+		name        string
+		datetime    string
+		height      int
+		grid        GridResolution
+		highlighted int
+	}{
+		{"All hours", "2024-10-26T23:40:00Z", 0, HourGrid, 0},
+		{"Scrolled to fit the terminal", "2024-10-27T14:00:00Z", 20, HourGrid, 2},
+		{"Half hours", "2024-10-27T01:00:00Z", 20, HalfHourGrid, 0},
+	}
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		clockTime, err := time.Parse(time.RFC3339, test.datetime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deadline, err := ParseDeadline("", "2024-10-27 06:30 UTC", clockTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := model{
+			zones:       []*Zone{zones[0], zones[1], zones[3], zones[8]},
+			deadlines:   []*Deadline{deadline},
+			clock:       *NewClockTime(clockTime),
+			isMilitary:  true,
+			showDates:   true,
+			layout:      VerticalLayout,
+			grid:        test.grid,
+			highlighted: test.highlighted,
+			termHeight:  test.height,
+		}
+		outputData[i] = txtar.File{
+			Name: fmt.Sprintf("%s (%s)", test.name, test.datetime),
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("Vertical layout: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
+
+func TestBands(t *testing.T) {
+	testDataFile := "testdata/view/test-bands.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones := LoadDstTestZones(t)
+	paris := *zones[1]
+	paris.Bands = []Band{
+		{Name: "focus", Start: 9, End: 12, Color: "#FFB000", Symbol: "*"},
+	}
+
+	tests := []struct {
+		name   string
+		layout Layout
+	}{
+		{"Grid", GridLayout},
+		{"Vertical", VerticalLayout},
+	}
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		state := utcMinuteAfterMidnightModel
+		state.zones = []*Zone{zones[0], &paris}
+		state.bands = []Band{
+			{Name: "work", Start: 9, End: 17, Color: "day", Symbol: "+"},
+			{Name: "sleep", Start: 23, End: 7, Color: "night", Symbol: "z"},
+		}
+		state.layout = test.layout
+		state.showHelp = true
+		state.interactive = true
+		outputData[i] = txtar.File{
+			Name: test.name,
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("Bands: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
+
+func TestWeekOverview(t *testing.T) {
+	testDataFile := "testdata/view/test-week-overview.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones := LoadDstTestZones(t)
+	paris := *zones[1]
+	paris.Holidays = []string{"2024-11-01"}
+	tests := []struct {
+		name     string
+		datetime string
+		width    int
+	}{
+		{"Week with a holiday", "2024-10-30T10:00:00Z", 100},
+		{"Week with a DST change", "2024-10-26T23:40:00Z", 100},
+		{"One column per hour", "2024-10-30T10:00:00Z", 50},
+	}
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		clockTime, err := time.Parse(time.RFC3339, test.datetime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := model{
+			zones:      []*Zone{zones[0], &paris, zones[3], zones[5]},
+			holidays:   []string{"2024-10-22"},
+			clock:      *NewClockTime(clockTime),
+			isMilitary: true,
+			layout:     WeekLayout,
+			termWidth:  test.width,
+		}
+		outputData[i] = txtar.File{
+			Name: fmt.Sprintf("%s (%s)", test.name, test.datetime),
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("Week overview: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	testDataFile := "testdata/view/test-calendar.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones := LoadDstTestZones(t)
+	pagoPago, err := ReadZoneFromString(time.Now(), "Pacific/Pago_Pago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		datetime    string
+		highlighted int
+		zones       []*Zone
+	}{
+		{"Home zone", "2024-10-31T05:00:00Z", 0, nil},
+		{"Ahead of the home zone", "2024-10-31T12:00:00Z", 2, nil},
+		{"Behind the home zone", "2024-10-31T05:00:00Z", 3, nil},
+		{"Month starting on Monday", "2024-07-15T12:00:00Z", 0, nil},
+		{"Two days behind the home zone", "2024-10-31T10:30:00Z", 2, []*Zone{zones[6], pagoPago}},
+	}
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		clockTime, err := time.Parse(time.RFC3339, test.datetime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := model{
+			zones:       []*Zone{zones[0], zones[6], zones[7]},
+			clock:       *NewClockTime(clockTime),
+			isMilitary:  true,
+			highlighted: test.highlighted,
+			layout:      CompactLayout,
+		}
+		if test.zones != nil {
+			state.zones = test.zones
+		}
+		state.openCalendar()
+		outputData[i] = txtar.File{
+			Name: fmt.Sprintf("%s (%s)", test.name, test.datetime),
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("Calendar: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
+
+func TestLocales(t *testing.T) {
+	testDataFile := "testdata/view/test-locales.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones := LoadDstTestZones(t)
+	tests := []struct {
+		name   string
+		locale string
+		layout Layout
+	}{
+		{"en", "en", GridLayout},
+		{"fr_FR.UTF-8", "fr_FR.UTF-8", GridLayout},
+		{"ja_JP.UTF-8", "ja_JP.UTF-8", GridLayout},
+		{"fr_FR.UTF-8, week overview", "fr_FR.UTF-8", WeekLayout},
+		{"fr_FR.UTF-8, vertical layout", "fr_FR.UTF-8", VerticalLayout},
+	}
+
+	oldLocale := locale
+	defer func() { locale = oldLocale }()
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		locale, err = LoadLocale(test.locale)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := utcMinuteAfterMidnightModel
+		state.zones = []*Zone{zones[0], zones[5]}
+		state.isMilitary = false
+		state.showHelp = true
+		state.interactive = true
+		if test.layout != GridLayout {
+			state.layout = test.layout
+			state.header = "{date}"
+			state.showDates = true
+			state.showHelp = false
+			state.termHeight = 20
+		}
+		outputData[i] = txtar.File{
+			Name: test.name,
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("Locales: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
+
+func TestAccessible(t *testing.T) {
+	testDataFile := "testdata/view/test-accessible.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones := LoadDstTestZones(t)
+	tests := []struct {
+		name        string
+		datetime    string
+		military    bool
+		highlighted int
+		ringing     map[int]bool
+	}{
+		{"Working hours", "2024-10-30T10:00:00Z", true, 0, nil},
+		{"Weekend, 12-hour time", "2024-11-02T10:00:00Z", false, 0, nil},
+		{"Highlighted and ringing", "2024-10-30T20:30:00Z", true, 2, map[int]bool{3: true}},
+	}
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		clockTime, err := time.Parse(time.RFC3339, test.datetime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deadline, err := ParseDeadline("Launch", "2024-11-04 09:00 UTC", clockTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := model{
+			zones:       []*Zone{zones[0], zones[1], zones[3], zones[6], zones[7]},
+			deadlines:   []*Deadline{deadline},
+			clock:       *NewClockTime(clockTime),
+			isMilitary:  test.military,
+			highlighted: test.highlighted,
+			ringing:     test.ringing,
+			accessible:  true,
+		}
+		outputData[i] = txtar.File{
+			Name: fmt.Sprintf("%s (%s)", test.name, test.datetime),
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("Accessible: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
+
+func TestDaylight(t *testing.T) {
+	testDataFile := "testdata/view/test-daylight.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones := LoadDstTestZones(t)
+	tromso := Zone{Loc: zones[1].Loc, DbName: zones[1].DbName, Name: "Tromsø", Coordinates: &Coordinates{69.65, 18.96}}
+	tests := []struct {
+		name     string
+		datetime string
+		military bool
+		layout   Layout
+	}{
+		{"Autumn", "2024-10-30T10:00:00Z", true, GridLayout},
+		{"Winter, 12-hour time", "2024-12-21T10:00:00Z", false, GridLayout},
+		{"Vertical", "2024-10-30T10:00:00Z", true, VerticalLayout},
+	}
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		clockTime, err := time.Parse(time.RFC3339, test.datetime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := model{
+			zones:      []*Zone{zones[0], zones[1], &tromso, zones[3], zones[8]},
+			clock:      *NewClockTime(clockTime),
+			isMilitary: test.military,
+			showSun:    true,
+			layout:     test.layout,
+			termHeight: 30,
+		}
+		outputData[i] = txtar.File{
+			Name: fmt.Sprintf("%s (%s)", test.name, test.datetime),
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("Daylight: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
+
+func TestWorldMap(t *testing.T) {
+	testDataFile := "testdata/view/test-world-map.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones := LoadDstTestZones(t)
+	tests := []struct {
+		name     string
+		datetime string
+		width    int
+	}{
+		{"Autumn morning in Europe", "2024-10-30T08:00:00Z", 100},
+		{"Northern summer, midnight in UTC", "2024-06-21T00:00:00Z", 100},
+		{"Narrow map", "2024-12-21T12:00:00Z", 60},
+	}
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		clockTime, err := time.Parse(time.RFC3339, test.datetime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := model{
+			zones:       []*Zone{zones[0], zones[1], zones[3], zones[5], zones[7], zones[8]},
+			clock:       *NewClockTime(clockTime),
+			isMilitary:  true,
+			highlighted: 2,
+			layout:      MapLayout,
+			termWidth:   test.width,
+		}
+		outputData[i] = txtar.File{
+			Name: fmt.Sprintf("%s (%s)", test.name, test.datetime),
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("World map: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
+
+func TestBigClock(t *testing.T) {
+	testDataFile := "testdata/view/test-big-clock.txt"
+	testData, err := txtar.ParseFile(testDataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones := LoadDstTestZones(t)
+	clockTime := time.Date(2024, 10, 30, 10, 7, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		military    bool
+		seconds     bool
+		highlighted int
+		width       int
+		height      int
+	}{
+		{"Highlighted zone", true, false, 2, 80, 0},
+		{"Scaled to a large terminal", true, false, 3, 120, 40},
+		{"12-hour time with seconds", false, true, 4, 100, 0},
+	}
+
+	var outputData = make([]txtar.File, len(tests))
+	for i, test := range tests {
+		state := model{
+			zones:        []*Zone{zones[0], zones[1], zones[3], zones[7]},
+			keymaps:      DefaultKeymaps,
+			clock:        *NewClockTime(clockTime),
+			isMilitary:   test.military,
+			showSeconds:  test.seconds,
+			highlighted:  test.highlighted,
+			showBigClock: true,
+			termWidth:    test.width,
+			termHeight:   test.height,
+		}
+		outputData[i] = txtar.File{
+			Name: test.name,
+			Data: []byte(stripAnsiControlSequences(state.View())),
+		}
+	}
+
+	archive := txtar.Archive{
+		Comment: testData.Comment,
+		Files: outputData,
+	}
+	os.WriteFile(testDataFile, txtar.Format(&archive), 0666)
+
+	for i, test := range tests {
+		var expected string = ""
+		if len(testData.Files) > i {
+			expected = stripAnsiControlSequencesAndNewline(testData.Files[i].Data)
+		}
+		observed := stripAnsiControlSequencesAndNewline(outputData[i].Data)
+		if expected != observed {
+			t.Errorf("Big clock: Mismatched %s: Check git diff %s", test.name, testDataFile)
+		}
+	}
+}
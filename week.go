@@ -0,0 +1,217 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/muesli/termenv"
+)
+
+// Activity of a zone at some hour, in the week overview.
+type Activity int
+
+const (
+	Asleep Activity = iota
+	Off
+	Working
+)
+
+// Symbol of the activity in the week overview heatmap.
+func (a Activity) symbol() string {
+	switch a {
+	case Working:
+		return "█"
+	case Off:
+		return "▒"
+	}
+	return "░"
+}
+
+// Width of the day labels of the week overview, like "Mon 02 ".
+const WeekDayLabelWidth = 7
+
+// Width of a row of the week overview, with `cellWidth` columns per hour.
+func weekRowWidth(cellWidth int) int {
+	return 2 + WeekDayLabelWidth + 24*cellWidth
+}
+
+// Columns per hour in the week overview: two when they fit.
+func weekCellWidth(width int) int {
+	if width >= weekRowWidth(2) {
+		return 2
+	}
+	return 1
+}
+
+// CheckHolidays validates dates of holidays, like "2024-12-25".
+func CheckHolidays(dates []string) error {
+	for _, date := range dates {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return fmt.Errorf("Invalid holiday %q: use dates like 2024-12-25", date)
+		}
+	}
+	return nil
+}
+
+// The week overview shows the seven days from the Monday of the clock's
+// week, in the clock's zone.
+func (m *model) weekStart() time.Time {
+	t := m.clock.t
+	return time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+}
+
+// Time of a cell of the week overview: an hour of a day of the week.
+func weekCell(start time.Time, day int, hour int) time.Time {
+	return time.Date(start.Year(), start.Month(), start.Day()+day, hour, 0, 0, 0, start.Location())
+}
+
+// Whether the date of `t` is a day off in the zone: a weekend, or a
+// holiday of the zone, or of every zone.
+func (m *model) isDayOff(zone *Zone, t time.Time) bool {
+	t = zone.currentTime(t)
+	return slices.Contains(weekEnds, t.Weekday()) || m.isHoliday(zone, t)
+}
+
+func (m *model) isHoliday(zone *Zone, t time.Time) bool {
+	date := zone.currentTime(t).Format(time.DateOnly)
+	return slices.Contains(m.holidays, date) || slices.Contains(zone.Holidays, date)
+}
+
+// Activity of the zone at `t`: the hours of sleep bands are asleep, and
+// those of work bands are working, except on days off.
+func (m *model) activity(zone *Zone, t time.Time) Activity {
+	band := BandAt(m.zoneBands(zone), zone.currentTime(t).Hour())
+	switch {
+	case band == nil:
+		return Off
+	case band.Kind == SleepBand:
+		return Asleep
+	case band.Kind == WorkBand && !m.isDayOff(zone, t):
+		return Working
+	}
+	return Off
+}
+
+// Activity of all zones at `t`: working when all are, asleep when any
+// is, or else off.
+func (m *model) overlap(t time.Time) Activity {
+	overlap := Working
+	for _, zone := range m.zones {
+		overlap = min(overlap, m.activity(zone, t))
+	}
+	return overlap
+}
+
+// Color of an activity in the overlap rows.
+func (a Activity) color() string {
+	switch a {
+	case Working:
+		return palette().Day
+	case Off:
+		return palette().Evening
+	}
+	return palette().Night
+}
+
+// Render a row of the week overview: the day label, a cell per hour and
+// a note.
+func weekRow(m *model, label string, cells func(hour int) (Activity, string), cursorHour int, cellWidth int, note string) string {
+	s := strings.Builder{}
+	s.WriteString("  ")
+	s.WriteString(dateTimeStyle(fitWidth(label, WeekDayLabelWidth)).String())
+	for hour := 0; hour < 24; hour++ {
+		activity, color := cells(hour)
+		symbol := activity.symbol()
+		if hour == cursorHour && term == termenv.Ascii {
+			symbol = "@"
+		}
+		s.WriteString(timelineCellStyle(strings.Repeat(symbol, cellWidth), color, hour == cursorHour))
+	}
+	if note != "" {
+		s.WriteString(dateTimeStyle(" " + note).String())
+	}
+	return s.String() + "\n"
+}
+
+// Render the week overview: a heatmap of the working, off and asleep
+// hours of each zone, for the seven days of the clock's week, followed
+// by the hours when they overlap.
+func weekZones(m *model, width int) string {
+	start := m.weekStart()
+	cellWidth := weekCellWidth(width)
+	cursor := func(day int) int {
+		if weekCell(start, day, 0).YearDay() == m.clock.t.YearDay() {
+			return m.clock.t.Hour()
+		}
+		return -1
+	}
+
+	// Hours of the clock's zone, every three hours
+	s := strings.Builder{}
+	axis := strings.Builder{}
+	for hour := 0; hour < 24; hour += 3 {
+		label := weekCell(start, 0, hour).Format("3PM")
+		if m.isMilitary {
+			label = weekCell(start, 0, hour).Format("15")
+		}
+		axis.WriteString(fitWidth(label, 3*cellWidth))
+	}
+	s.WriteString(fmt.Sprintf("  %s%s\n", strings.Repeat(" ", WeekDayLabelWidth), dateTimeStyle(axis.String())))
+
+	for i, zone := range m.zones {
+		timeInZone := zone.currentTime(m.clock.t)
+		s.WriteString(fmt.Sprintf(
+			"%s%s %s\n",
+			m.rowMarker(i),
+			normalTextStyle(m.formatZoneName(zone, timeInZone, fractionalOffsetNote(timeInZone, false))),
+			dateTimeStyle(m.formatZoneTime(zone, timeInZone)),
+		))
+		for day := 0; day < 7; day++ {
+			cells := func(hour int) (Activity, string) {
+				t := weekCell(start, day, hour)
+				return m.activity(zone, t), m.hourColor(zone, zone.currentTime(t).Hour())
+			}
+			var note string
+			noon := weekCell(start, day, 12)
+			if m.isHoliday(zone, noon) {
+				note = tr("holiday")
+			} else if m.isDayOff(zone, noon) {
+				note = tr("weekend")
+			}
+			s.WriteString(weekRow(m, formatLocal(weekCell(start, day, 0), "Mon 02"), cells, cursor(day), cellWidth, note))
+		}
+	}
+
+	if len(m.zones) > 1 {
+		s.WriteString(fmt.Sprintf("  %s\n", normalTextStyle(tr("Overlap"))))
+		for day := 0; day < 7; day++ {
+			cells := func(hour int) (Activity, string) {
+				overlap := m.overlap(weekCell(start, day, hour))
+				return overlap, overlap.color()
+			}
+			s.WriteString(weekRow(m, formatLocal(weekCell(start, day, 0), "Mon 02"), cells, cursor(day), cellWidth, ""))
+		}
+	}
+
+	legend := fmt.Sprintf("%s %s  %s %s  %s %s", Working.symbol(), tr("working"), Off.symbol(), tr("off"), Asleep.symbol(), tr("asleep"))
+	s.WriteString(fmt.Sprintf("  %s%s\n", strings.Repeat(" ", WeekDayLabelWidth), dateTimeStyle(legend)))
+	return s.String()
+}
@@ -0,0 +1,121 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckHolidays(t *testing.T) {
+	if err := CheckHolidays([]string{"2024-12-25", "2025-01-01"}); err != nil {
+		t.Errorf("Expected valid holidays: %v", err)
+	}
+	for _, date := range []string{"", "25/12/2024", "2024-13-01", "2024-12-25 00:00"} {
+		if err := CheckHolidays([]string{date}); err == nil {
+			t.Errorf("Expected holiday %q to be invalid", date)
+		}
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		clock    string
+		expected string
+	}{
+		{"2024-10-28T00:00:00Z", "2024-10-28T00:00:00Z"},
+		{"2024-10-30T10:00:00Z", "2024-10-28T00:00:00Z"},
+		{"2024-11-03T23:59:00Z", "2024-10-28T00:00:00Z"},
+		{"2024-01-03T12:00:00Z", "2024-01-01T00:00:00Z"},
+	}
+	for _, test := range tests {
+		clock, _ := time.Parse(time.RFC3339, test.clock)
+		m := model{clock: *NewClockTime(clock)}
+		if start := m.weekStart().Format(time.RFC3339); start != test.expected {
+			t.Errorf("Expected the week of %s to start on %s, but got %s", test.clock, test.expected, start)
+		}
+	}
+}
+
+func TestActivity(t *testing.T) {
+	zone := &Zone{Loc: time.UTC, Name: "UTC", Holidays: []string{"2024-10-31"}}
+	m := model{zones: []*Zone{zone}, holidays: []string{"2024-10-29"}}
+	tests := []struct {
+		time     string
+		expected Activity
+	}{
+		{"2024-10-28T03:00:00Z", Asleep},
+		{"2024-10-28T08:00:00Z", Off},
+		{"2024-10-28T10:00:00Z", Working},
+		{"2024-10-29T10:00:00Z", Off}, // Holiday of every zone
+		{"2024-10-30T10:00:00Z", Working},
+		{"2024-10-31T10:00:00Z", Off}, // Holiday of the zone
+		{"2024-11-02T10:00:00Z", Off}, // Saturday
+		{"2024-11-02T22:00:00Z", Asleep},
+	}
+	for _, test := range tests {
+		at, _ := time.Parse(time.RFC3339, test.time)
+		if activity := m.activity(zone, at); activity != test.expected {
+			t.Errorf("Expected activity %v at %s, but got %v", test.expected, test.time, activity)
+		}
+	}
+}
+
+func TestActivityOfConfiguredBands(t *testing.T) {
+	zone := &Zone{Loc: time.UTC, Name: "UTC"}
+	m := model{
+		zones: []*Zone{zone},
+		bands: []Band{
+			{Name: "office", Start: 10, End: 19, Color: "day", Kind: WorkBand},
+			{Name: "lunch", Start: 12, End: 13, Color: "evening"},
+			{Name: "bed", Start: 23, End: 7, Color: "night", Kind: SleepBand},
+			{Name: "day", Start: 7, End: 10, Color: "day"},
+		},
+	}
+	tests := []struct {
+		time     string
+		expected Activity
+	}{
+		{"2024-10-28T03:00:00Z", Asleep},
+		{"2024-10-28T08:00:00Z", Off}, // Named day, but not of the work kind
+		{"2024-10-28T11:00:00Z", Working},
+		{"2024-10-28T20:00:00Z", Off},
+	}
+	for _, test := range tests {
+		at, _ := time.Parse(time.RFC3339, test.time)
+		if activity := m.activity(zone, at); activity != test.expected {
+			t.Errorf("Expected activity %v at %s, but got %v", test.expected, test.time, activity)
+		}
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	utc := &Zone{Loc: time.UTC, Name: "UTC"}
+	sydney := &Zone{Loc: time.FixedZone("AEDT", 11*60*60), Name: "Sydney"}
+	m := model{zones: []*Zone{utc, sydney}}
+
+	// 22:00 UTC is 9AM in Sydney, after 7AM in UTC
+	at := time.Date(2024, 10, 29, 22, 0, 0, 0, time.UTC)
+	if overlap := m.overlap(at); overlap != Asleep {
+		t.Errorf("Expected no overlap while UTC sleeps, but got %v", overlap)
+	}
+	m.zones = []*Zone{utc}
+	at = time.Date(2024, 10, 29, 10, 0, 0, 0, time.UTC)
+	if overlap := m.overlap(at); overlap != Working {
+		t.Errorf("Expected a single zone to overlap with itself, but got %v", overlap)
+	}
+}
@@ -0,0 +1,195 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// Land of the world, drawn coarsely by hand: a character per 2° of
+// latitude and longitude, # for land, from 90°N and 180°W.
+//
+//go:embed maps/world.txt
+var worldMapData string
+
+var worldMap = strings.Split(strings.TrimSpace(worldMapData), "\n")
+
+// Degrees per character of the land mask.
+const worldMapResolution = 2
+
+// Latitudes shown on the map: from the north of Greenland to Cape Horn.
+const (
+	MapNorth = 84.0
+	MapSouth = -58.0
+)
+
+// Below this many columns, the map is unreadable: use the compact layout
+// instead.
+const MinimumMapColumns = 48
+
+// Whether there is land at the coordinates.
+func isLand(latitude float64, longitude float64) bool {
+	row := int((90 - latitude) / worldMapResolution)
+	column := int((longitude + 180) / worldMapResolution)
+	if row < 0 || row >= len(worldMap) {
+		return false
+	}
+	line := worldMap[row]
+	column = ((column % len(line)) + len(line)) % len(line)
+	return line[column] == '#'
+}
+
+// Size of the map in `width` columns: characters are about twice as high
+// as they are wide, so rows cover twice the degrees of columns.
+func mapSize(width int) (columns int, rows int) {
+	columns = width - 4
+	rows = int(math.Round((MapNorth - MapSouth) * float64(columns) / 720))
+	return columns, rows
+}
+
+// Coordinates of a point of the map, in fractions of its columns and rows.
+func mapCoordinates(columns int, rows int, x float64, y float64) Coordinates {
+	return Coordinates{
+		Latitude:  MapNorth - y*(MapNorth-MapSouth)/float64(rows),
+		Longitude: -180 + x*360/float64(columns),
+	}
+}
+
+// Dots of a braille character, by their column and row in the character.
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// Draw the land of a character of the map in braille dots, 2 by 4.
+func brailleLand(columns int, rows int, column int, row int) rune {
+	dots := rune(0)
+	for dy := range brailleDots {
+		for dx := range brailleDots[dy] {
+			c := mapCoordinates(
+				columns,
+				rows,
+				float64(column)+(float64(dx)+0.5)/2,
+				float64(row)+(float64(dy)+0.5)/4,
+			)
+			if isLand(c.Latitude, c.Longitude) {
+				dots |= brailleDots[dy][dx]
+			}
+		}
+	}
+	return 0x2800 + dots
+}
+
+// Character of the map at a column and row: land in braille, colored by
+// daylight, and dotted sea at night. In plain text, land is # in
+// daylight and + at night, and the sea at night is dotted.
+func (m *model) mapCharacter(columns int, rows int, column int, row int) string {
+	center := mapCoordinates(columns, rows, float64(column)+0.5, float64(row)+0.5)
+	up := sunUp(m.clock.t, center)
+	land := isLand(center.Latitude, center.Longitude)
+
+	if term == termenv.Ascii {
+		switch {
+		case land && up:
+			return "#"
+		case land:
+			return "+"
+		case up:
+			return " "
+		}
+		return "."
+	}
+
+	color := palette().Night
+	if up {
+		color = palette().Day
+	}
+	braille := brailleLand(columns, rows, column, row)
+	switch {
+	case braille != 0x2800:
+		return termenv.String(string(braille)).Foreground(term.Color(color)).String()
+	case up:
+		return " "
+	}
+	return termenv.String("·").Foreground(term.Color(color)).String()
+}
+
+// Labels of the pins of the zones on the map, by row number.
+const pinLabels = "123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Label of the pin of the zone at a row, from 0.
+func pinLabel(row int) string {
+	if row >= len(pinLabels) {
+		return "*"
+	}
+	return pinLabels[row : row+1]
+}
+
+// Render a map of the world, with daylight at the clock's time and a pin
+// for each zone, followed by the zones with their pins.
+func mapZones(m *model, width int) string {
+	columns, rows := mapSize(width)
+
+	pins := make(map[[2]int]string)
+	for i, zone := range m.zones {
+		if c, ok := zone.coordinates(); ok {
+			column := int((c.Longitude + 180) * float64(columns) / 360)
+			row := int((MapNorth - c.Latitude) * float64(rows) / (MapNorth - MapSouth))
+			pins[[2]int{min(column, columns-1), row}] = highlightStyle(pinLabel(i)).String()
+		}
+	}
+
+	s := strings.Builder{}
+	for row := 0; row < rows; row++ {
+		s.WriteString("  ")
+		for column := 0; column < columns; column++ {
+			if pin, ok := pins[[2]int{column, row}]; ok {
+				s.WriteString(pin)
+			} else {
+				s.WriteString(m.mapCharacter(columns, rows, column, row))
+			}
+		}
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	for i, zone := range m.zones {
+		timeInZone := zone.currentTime(m.clock.t)
+		label := " "
+		note := ""
+		if _, ok := zone.coordinates(); ok {
+			label = pinLabel(i)
+			note = "  " + m.sunNote(zone)
+		}
+		s.WriteString(fmt.Sprintf(
+			"%s%s %s  %s%s\n",
+			m.rowMarker(i),
+			label,
+			normalTextStyle(zone.Name),
+			dateTimeStyle(m.formatZoneTime(zone, timeInZone)),
+			note,
+		))
+	}
+	return s.String()
+}
@@ -0,0 +1,67 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import "testing"
+
+func TestWorldMapData(t *testing.T) {
+	if len(worldMap) != 180/worldMapResolution {
+		t.Fatalf("Expected %d rows in the world map, but got %d", 180/worldMapResolution, len(worldMap))
+	}
+	for i, line := range worldMap {
+		if len(line) != 360/worldMapResolution {
+			t.Errorf("Expected %d columns in row %d of the world map, but got %d", 360/worldMapResolution, i, len(line))
+		}
+	}
+}
+
+func TestIsLand(t *testing.T) {
+	places := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		land      bool
+	}{
+		{"Sahara", 23, 10, true},
+		{"Kansas", 38, -98, true},
+		{"Central Australia", -25, 133, true},
+		{"Siberia", 62, 100, true},
+		{"Pacific Ocean", 0, -140, false},
+		{"Atlantic Ocean", 30, -40, false},
+		{"Caspian Sea", 42, 51, false},
+		{"Across the antimeridian", 0, 180 + 360, false},
+	}
+	for _, place := range places {
+		if isLand(place.latitude, place.longitude) != place.land {
+			t.Errorf("Expected land at %s: %v", place.name, place.land)
+		}
+	}
+}
+
+func TestBrailleLand(t *testing.T) {
+	columns, rows := mapSize(100)
+	column := int((10.0 + 180) * float64(columns) / 360)
+	row := int((MapNorth - 23) * float64(rows) / (MapNorth - MapSouth))
+	if braille := brailleLand(columns, rows, column, row); braille != '⣿' {
+		t.Errorf("Expected the Sahara to be all land, but got %q", braille)
+	}
+	column = int((-140.0 + 180) * float64(columns) / 360)
+	row = int(MapNorth * float64(rows) / (MapNorth - MapSouth))
+	if braille := brailleLand(columns, rows, column, row); braille != '⠀' {
+		t.Errorf("Expected the Pacific to be all sea, but got %q", braille)
+	}
+}
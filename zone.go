@@ -57,6 +57,12 @@ type Zone struct {
 	Loc    *time.Location
 	DbName string // Name in tzdata
 	Name   string // Preferred name (user-provided, or else DbName by default)
+	Bands  []Band // Times of day, when they differ from the other zones'
+
+	Holidays []string // Days off in the zone, as "2006-01-02"
+	Military *bool    // 24-hour time in the zone, unless nil for the default
+
+	Coordinates *Coordinates // For sunrise and sunset, unless nil for the tzdata ones
 }
 
 func (z Zone) String() string {
@@ -82,12 +88,23 @@ func (z Zone) ClockEmoji(t time.Time) string {
 
 // ShortDT returns the current time in short format.
 func (z Zone) ShortDT(t time.Time) string {
-	return z.currentTime(t).Format("3:04PM, Mon Jan 02, 2006")
+	return formatLocal(z.currentTime(t), "3:04PM, Mon Jan 02, 2006")
 }
 
 // ShortMT returns the current military time in short format.
 func (z Zone) ShortMT(t time.Time) string {
-	return z.currentTime(t).Format("15:04, Mon Jan 02, 2006")
+	return formatLocal(z.currentTime(t), "15:04, Mon Jan 02, 2006")
+}
+
+// ShortDTSeconds returns the current time in short format, with seconds.
+func (z Zone) ShortDTSeconds(t time.Time) string {
+	return formatLocal(z.currentTime(t), "3:04:05PM, Mon Jan 02, 2006")
+}
+
+// ShortMTSeconds returns the current military time in short format, with
+// seconds.
+func (z Zone) ShortMTSeconds(t time.Time) string {
+	return formatLocal(z.currentTime(t), "15:04:05, Mon Jan 02, 2006")
 }
 
 func (z Zone) currentTime(t time.Time) time.Time {
@@ -0,0 +1,151 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	// Fall back to the tz database embedded in the binary, when the host
+	// has none.
+	_ "time/tzdata"
+)
+
+// Where the standard library looks for the host's tz database, after
+// $ZONEINFO, on Unix systems.
+var systemZoneInfoDirs = []string{
+	"/usr/share/zoneinfo/",
+	"/usr/share/lib/zoneinfo/",
+	"/usr/lib/locale/TZ/",
+	"/etc/zoneinfo/",
+}
+
+// ZoneInfo is a custom source of zone rules: a zoneinfo directory, or a
+// zip archive like Go's zoneinfo.zip.
+type ZoneInfo struct {
+	Path string
+	fsys fs.FS
+}
+
+// The custom source of zone rules, or nil for the host's tz database,
+// with the embedded one as a fallback.
+var zoneInfo *ZoneInfo
+
+// OpenZoneInfo opens a zoneinfo directory, or zip archive.
+func OpenZoneInfo(path string) (*ZoneInfo, error) {
+	if rest, found := strings.CutPrefix(path, "~/"); found {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(homeDir, rest)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening zoneinfo: %w", err)
+	}
+	if info.IsDir() {
+		return &ZoneInfo{Path: path, fsys: os.DirFS(path)}, nil
+	}
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening zoneinfo %s: %w", path, err)
+	}
+	return &ZoneInfo{Path: path, fsys: archive}, nil
+}
+
+// SetZoneInfo loads zones from the zoneinfo directory, or zip archive at
+// `path` from now on.
+func SetZoneInfo(path string) error {
+	zi, err := OpenZoneInfo(path)
+	if err != nil {
+		return err
+	}
+	zoneInfo = zi
+	logger.Printf("Using zoneinfo from %s", path)
+	return nil
+}
+
+// LoadLocation is time.LoadLocation, reading zones from the custom
+// zoneinfo source when one is set.
+func LoadLocation(name string) (*time.Location, error) {
+	if zoneInfo == nil || name == "" || name == "UTC" || name == "Local" {
+		return time.LoadLocation(name)
+	}
+	if !fs.ValidPath(name) {
+		return nil, fmt.Errorf("invalid zone name %q", name)
+	}
+	data, err := fs.ReadFile(zoneInfo.fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %s in %s", name, zoneInfo.Path)
+	}
+	return time.LoadLocationFromTZData(name, data)
+}
+
+// Version of the tz database in the custom source, from its +VERSION or
+// tzdata.zi files, as installed by most distributions.
+func (zi ZoneInfo) Version() string {
+	if data, err := fs.ReadFile(zi.fsys, "+VERSION"); err == nil {
+		return strings.TrimSpace(string(data))
+	}
+	if f, err := zi.fsys.Open("tzdata.zi"); err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		if scanner.Scan() {
+			if version, found := strings.CutPrefix(scanner.Text(), "# version "); found {
+				return version
+			}
+		}
+	}
+	return "unknown version"
+}
+
+// TzDataSource describes where zones are loaded from, and the version of
+// the tz database there. The embedded copy has no version of its own: it
+// is the one of the Go release tz was built with.
+func TzDataSource() string {
+	if zoneInfo != nil {
+		return fmt.Sprintf("%s (%s)", zoneInfo.Path, zoneInfo.Version())
+	}
+
+	dirs := systemZoneInfoDirs
+	if runtime.GOOS == "windows" {
+		dirs = nil
+	}
+	if env := os.Getenv("ZONEINFO"); env != "" {
+		dirs = append([]string{env}, dirs...)
+	}
+	for _, dir := range dirs {
+		zi, err := OpenZoneInfo(dir)
+		if err != nil {
+			continue
+		}
+		if data, err := fs.ReadFile(zi.fsys, "UTC"); err == nil && bytes.HasPrefix(data, []byte("TZif")) {
+			return fmt.Sprintf("%s (%s)", dir, zi.Version())
+		}
+	}
+	return fmt.Sprintf("embedded in %s", runtime.Version())
+}
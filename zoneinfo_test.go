@@ -0,0 +1,112 @@
+/**
+ * This file is part of tz.
+ *
+ * tz is free software: you can redistribute it and/or modify it under
+ * the terms of the GNU General Public License as published by the Free
+ * Software Foundation, either version 3 of the License, or (at your
+ * option) any later version.
+ *
+ * tz is distributed in the hope that it will be useful, but WITHOUT
+ * ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+ * or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public
+ * License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with tz.  If not, see <https://www.gnu.org/licenses/>.
+ **/
+package main
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A zoneinfo directory with only Europe/Paris.
+const testZoneInfoDir = "testdata/zoneinfo"
+
+// Zip the test zoneinfo directory, like Go's zoneinfo.zip.
+func zipTestZoneInfo(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "zoneinfo.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	archive := zip.NewWriter(f)
+	for _, name := range []string{"+VERSION", "Europe/Paris"} {
+		data, err := os.ReadFile(filepath.Join(testZoneInfoDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCustomZoneInfo(t *testing.T) {
+	defer func() { zoneInfo = nil }()
+
+	for _, path := range []string{testZoneInfoDir, zipTestZoneInfo(t)} {
+		if err := SetZoneInfo(path); err != nil {
+			t.Fatalf("Could not use zoneinfo from %s: %v", path, err)
+		}
+
+		loc, err := LoadLocation("Europe/Paris")
+		if err != nil {
+			t.Fatalf("Could not load Europe/Paris from %s: %v", path, err)
+		}
+		summer := time.Date(2024, time.July, 1, 0, 0, 0, 0, loc)
+		if name, _ := summer.Zone(); name != "CEST" {
+			t.Errorf("Expected CEST in summer from %s, but got %v", path, name)
+		}
+
+		// Only from the custom zoneinfo...
+		if _, err := LoadLocation("Asia/Tokyo"); err == nil {
+			t.Errorf("Expected Asia/Tokyo to be missing from %s", path)
+		}
+		if _, err := LoadLocation("../zoneinfo/Europe/Paris"); err == nil {
+			t.Errorf("Expected invalid zone name to be rejected")
+		}
+		// ...except for the zones that do not need one.
+		if _, err := LoadLocation("UTC"); err != nil {
+			t.Errorf("Expected UTC from %s, but got: %v", path, err)
+		}
+
+		source := TzDataSource()
+		if !strings.HasPrefix(source, path) || !strings.Contains(source, "(2025b)") {
+			t.Errorf("Expected %s version 2025b to be reported, but got: %v", path, source)
+		}
+	}
+
+	if _, err := OpenZoneInfo("testdata/missing"); err == nil {
+		t.Errorf("Expected missing zoneinfo to fail")
+	}
+}
+
+func TestEmbeddedZoneInfo(t *testing.T) {
+	oldZoneInfo := os.Getenv("ZONEINFO")
+	oldSystemDirs := systemZoneInfoDirs
+	os.Unsetenv("ZONEINFO")
+	systemZoneInfoDirs = nil
+	defer func() {
+		os.Setenv("ZONEINFO", oldZoneInfo)
+		systemZoneInfoDirs = oldSystemDirs
+	}()
+
+	if source := TzDataSource(); source != "embedded in "+runtime.Version() {
+		t.Errorf("Expected embedded tzdata to be reported, but got: %v", source)
+	}
+}